@@ -0,0 +1,274 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/exp/maps"
+)
+
+// ErrDuplicateValue is returned when putting a value into a BiMap that is already associated with another key.
+var ErrDuplicateValue = errors.New("kol: duplicate value")
+
+// BiMap is a map that keeps both keys and values unique, so that it can be looked up by either side.
+type BiMap[K comparable, V comparable] interface {
+	// Clear removes all entries.
+	Clear()
+	// ContainsKey returns `true` if this map contains the given key.
+	ContainsKey(key K) bool
+	// ContainsValue returns `true` if this map contains the given value.
+	ContainsValue(value V) bool
+	// Entries returns a sequence of all key-value pairs.
+	Entries() Sequence[Pair[K, V]]
+	// ForcePut associates the given value with the given key,
+	// removing the entry of another key already associated with the value.
+	ForcePut(key K, value V)
+	// Format implements fmt.Formatter. A map is formatted as `{k1: v1, k2: v2}` in a deterministic sorted order of keys.
+	// %+v prefixes the entries with the type and the size,
+	// and %.Nv formats only the first N keys followed by "...and M more".
+	Format(f fmt.State, verb rune)
+	// GetByKey returns the value associated with the given key.
+	// If there is no such key, it returns `false` as a second return value.
+	GetByKey(key K) (V, bool)
+	// GetByValue returns the key associated with the given value.
+	// If there is no such value, it returns `false` as a second return value.
+	GetByValue(value V) (K, bool)
+	// Inverse returns a view of this map with keys and values swapped.
+	// Modifications of either map are reflected in the other.
+	Inverse() BiMap[V, K]
+	// IsEmpty returns `true` if this map has no entries.
+	IsEmpty() bool
+	// KeySet returns a set of the keys.
+	KeySet() Set[K]
+	// LogValue implements slog.LogValuer, and returns a group of the type, the size
+	// and a sample of the entries limited by SetLogSampleSize, in the same order as Format.
+	LogValue() slog.Value
+	// Put associates the given value with the given key, replacing the previous value of the key.
+	// It returns ErrDuplicateValue if the value is already associated with another key.
+	Put(key K, value V) error
+	// RemoveByKey removes the entry of the given key and returns its value.
+	// If there is no such key, it returns `false` as a second return value.
+	RemoveByKey(key K) (V, bool)
+	// RemoveByValue removes the entry of the given value and returns its key.
+	// If there is no such value, it returns `false` as a second return value.
+	RemoveByValue(value V) (K, bool)
+	// Size returns the number of entries.
+	Size() int
+	// String returns the entries formatted by %v.
+	String() string
+	// ValueSet returns a set of the values.
+	ValueSet() Set[V]
+}
+
+type biMap[K comparable, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+}
+
+var _ BiMap[int, string] = (*biMap[int, string])(nil)
+
+// NewBiMap returns an empty BiMap.
+func NewBiMap[K comparable, V comparable]() BiMap[K, V] {
+	return &biMap[K, V]{forward: make(map[K]V), backward: make(map[V]K)}
+}
+
+// NewBiMapFromPairs returns a BiMap containing the given key-value pairs.
+// A later pair with the same key replaces the earlier one.
+// It returns ErrDuplicateValue if a value is associated with more than one key.
+func NewBiMapFromPairs[K comparable, V comparable](pairs List[Pair[K, V]]) (BiMap[K, V], error) {
+	b := NewBiMap[K, V]()
+	for _, p := range pairs.ToSlice() {
+		if err := b.Put(p.First, p.Second); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (b *biMap[K, V]) Clear() {
+	clear(b.forward)
+	clear(b.backward)
+}
+
+func (b *biMap[K, V]) ContainsKey(k K) bool {
+	_, ok := b.forward[k]
+	return ok
+}
+
+func (b *biMap[K, V]) ContainsValue(v V) bool {
+	_, ok := b.backward[v]
+	return ok
+}
+
+func (b *biMap[K, V]) Entries() Sequence[Pair[K, V]] {
+	entries := make([]Pair[K, V], 0, len(b.forward))
+	for k, v := range b.forward {
+		entries = append(entries, NewPair(k, v))
+	}
+	return NewSequence(entries...)
+}
+
+func (b *biMap[K, V]) ForcePut(k K, v V) {
+	b.RemoveByValue(v)
+	b.RemoveByKey(k)
+	b.forward[k] = v
+	b.backward[v] = k
+}
+
+func (b *biMap[K, V]) GetByKey(k K) (V, bool) {
+	v, ok := b.forward[k]
+	return v, ok
+}
+
+func (b *biMap[K, V]) GetByValue(v V) (K, bool) {
+	k, ok := b.backward[v]
+	return k, ok
+}
+
+func (b *biMap[K, V]) Inverse() BiMap[V, K] {
+	return &biMap[V, K]{forward: b.backward, backward: b.forward}
+}
+
+func (b *biMap[K, V]) IsEmpty() bool {
+	return len(b.forward) == 0
+}
+
+func (b *biMap[K, V]) KeySet() Set[K] {
+	return NewSet(maps.Keys(b.forward)...)
+}
+
+func (b *biMap[K, V]) Put(k K, v V) error {
+	if owner, ok := b.backward[v]; ok {
+		if owner == k {
+			return nil
+		}
+		return ErrDuplicateValue
+	}
+	b.ForcePut(k, v)
+	return nil
+}
+
+func (b *biMap[K, V]) RemoveByKey(k K) (V, bool) {
+	v, ok := b.forward[k]
+	if ok {
+		delete(b.forward, k)
+		delete(b.backward, v)
+	}
+	return v, ok
+}
+
+func (b *biMap[K, V]) RemoveByValue(v V) (K, bool) {
+	k, ok := b.backward[v]
+	if ok {
+		delete(b.backward, v)
+		delete(b.forward, k)
+	}
+	return k, ok
+}
+
+func (b *biMap[K, V]) Size() int {
+	return len(b.forward)
+}
+
+func (b *biMap[K, V]) ValueSet() Set[V] {
+	return NewSet(maps.Values(b.forward)...)
+}
+
+var (
+	_ json.Marshaler   = (*biMap[int, string])(nil)
+	_ json.Unmarshaler = (*biMap[int, string])(nil)
+)
+
+// MarshalJSON encodes this map as a JSON object, so keys must be strings, integers or encoding.TextMarshaler.
+func (b *biMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.forward)
+}
+
+// UnmarshalJSON replaces the entries of this map with the decoded JSON object.
+// It returns ErrDuplicateValue without modifying this map if a value is associated with more than one key.
+func (b *biMap[K, V]) UnmarshalJSON(data []byte) error {
+	var forward map[K]V
+	if err := json.Unmarshal(data, &forward); err != nil {
+		return err
+	}
+	backward := make(map[V]K, len(forward))
+	for k, v := range forward {
+		if _, ok := backward[v]; ok {
+			return ErrDuplicateValue
+		}
+		backward[v] = k
+	}
+	b.Clear()
+	maps.Copy(b.forward, forward)
+	maps.Copy(b.backward, backward)
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*biMap[int, string])(nil)
+	_ encoding.BinaryUnmarshaler = (*biMap[int, string])(nil)
+	_ gob.GobEncoder             = (*biMap[int, string])(nil)
+	_ gob.GobDecoder             = (*biMap[int, string])(nil)
+)
+
+// MarshalBinary encodes this map into a compact binary form.
+func (b *biMap[K, V]) MarshalBinary() ([]byte, error) {
+	keys := make([]K, 0, len(b.forward))
+	values := make([]V, 0, len(b.forward))
+	for k, v := range b.forward {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return marshalBinaryEntries(keys, values)
+}
+
+// UnmarshalBinary replaces the entries of this map with the decoded binary form.
+// It returns ErrDuplicateValue without modifying this map if a value is associated with more than one key.
+func (b *biMap[K, V]) UnmarshalBinary(data []byte) error {
+	keys, values, err := unmarshalBinaryEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+	decoded := &biMap[K, V]{forward: make(map[K]V, len(keys)), backward: make(map[V]K, len(keys))}
+	for i, k := range keys {
+		if err := decoded.Put(k, values[i]); err != nil {
+			return err
+		}
+	}
+	b.Clear()
+	maps.Copy(b.forward, decoded.forward)
+	maps.Copy(b.backward, decoded.backward)
+	return nil
+}
+
+// GobEncode encodes this map by MarshalBinary.
+func (b *biMap[K, V]) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+// GobDecode decodes this map by UnmarshalBinary.
+func (b *biMap[K, V]) GobDecode(data []byte) error {
+	return b.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*biMap[int, string])(nil)
+	_ fmt.Stringer   = (*biMap[int, string])(nil)
+	_ slog.LogValuer = (*biMap[int, string])(nil)
+)
+
+func (b *biMap[K, V]) Format(f fmt.State, verb rune) {
+	formatMap(f, verb, mapTypeName[K, V]("BiMap"), len(b.forward), b.forward)
+}
+
+func (b *biMap[K, V]) String() string {
+	return fmt.Sprint(b)
+}
+
+func (b *biMap[K, V]) LogValue() slog.Value {
+	return logMap(mapTypeName[K, V]("BiMap"), len(b.forward), b.forward)
+}
@@ -0,0 +1,117 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMap_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		value     int
+		wantErr   error
+		wantPairs []Pair[string, int]
+	}{
+		{
+			name:      "new entry",
+			key:       "c",
+			value:     3,
+			wantPairs: []Pair[string, int]{NewPair("a", 1), NewPair("b", 2), NewPair("c", 3)},
+		},
+		{
+			name:      "replace value of existing key",
+			key:       "a",
+			value:     3,
+			wantPairs: []Pair[string, int]{NewPair("a", 3), NewPair("b", 2)},
+		},
+		{
+			name:      "same entry",
+			key:       "a",
+			value:     1,
+			wantPairs: []Pair[string, int]{NewPair("a", 1), NewPair("b", 2)},
+		},
+		{
+			name:      "duplicate value",
+			key:       "c",
+			value:     1,
+			wantErr:   ErrDuplicateValue,
+			wantPairs: []Pair[string, int]{NewPair("a", 1), NewPair("b", 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+			assert.NoError(t, err)
+			assert.ErrorIs(t, b.Put(tt.key, tt.value), tt.wantErr)
+			assert.ElementsMatch(t, tt.wantPairs, b.Entries().ToSlice())
+			assert.Equal(t, b.KeySet().Size(), b.ValueSet().Size())
+		})
+	}
+}
+
+func TestBiMap_ForcePut(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+	b.ForcePut("a", 2)
+	assert.ElementsMatch(t, []Pair[string, int]{NewPair("a", 2)}, b.Entries().ToSlice())
+	_, ok := b.GetByValue(1)
+	assert.False(t, ok)
+	assert.False(t, b.ContainsKey("b"))
+}
+
+func TestBiMap_Get(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+	v, ok := b.GetByKey("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	k, ok := b.GetByValue(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", k)
+	_, ok = b.GetByKey("c")
+	assert.False(t, ok)
+}
+
+func TestBiMap_Remove(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+	v, ok := b.RemoveByKey("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.False(t, b.ContainsValue(1))
+	k, ok := b.RemoveByValue(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", k)
+	assert.True(t, b.IsEmpty())
+	_, ok = b.RemoveByKey("a")
+	assert.False(t, ok)
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+	inverse := b.Inverse()
+	k, _ := inverse.GetByKey(1)
+	assert.Equal(t, "a", k)
+	assert.ElementsMatch(t, []int{1, 2}, inverse.KeySet().ToSlice())
+	assert.ElementsMatch(t, []string{"a", "b"}, inverse.ValueSet().ToSlice())
+
+	assert.NoError(t, inverse.Put(3, "c"))
+	v, ok := b.GetByKey("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	b.RemoveByKey("a")
+	assert.False(t, inverse.ContainsKey(1))
+	assert.Equal(t, b.Size(), inverse.Size())
+
+	b.Clear()
+	assert.True(t, inverse.IsEmpty())
+}
+
+func TestNewBiMapFromPairs(t *testing.T) {
+	_, err := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 1)))
+	assert.ErrorIs(t, err, ErrDuplicateValue)
+
+	b, err := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("a", 2)))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Pair[string, int]{NewPair("a", 2)}, b.Entries().ToSlice())
+}
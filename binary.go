@@ -0,0 +1,223 @@
+package kol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// binaryVersion is the first byte of binary forms of collections, which is incremented on incompatible changes.
+const binaryVersion byte = 1
+
+// Formats of elements in binary forms of collections.
+const (
+	// binaryCompact encodes the number of elements and each element of a boolean, numeric or string kind.
+	binaryCompact byte = iota
+	// binaryGob encodes a slice of elements of other kinds by encoding/gob.
+	binaryGob
+)
+
+var (
+	// ErrUnsupportedBinaryVersion is returned when decoding a binary form of an unknown version.
+	ErrUnsupportedBinaryVersion = errors.New("kol: unsupported binary version")
+	// ErrMalformedBinary is returned when decoding a corrupted binary form.
+	ErrMalformedBinary = errors.New("kol: malformed binary data")
+)
+
+// RegisterGob registers the List and Set implementations for elements of type E with encoding/gob,
+// so that fields typed as List[E] or Set[E] can be encoded and decoded by gob.
+func RegisterGob[E comparable]() {
+	gob.Register(NewList[E]())
+	gob.Register(NewSet[E]())
+}
+
+func compactKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// marshalBinaryElements encodes the given elements into a binary form.
+func marshalBinaryElements[E comparable](elements []E) ([]byte, error) {
+	if !compactKind(reflect.TypeFor[E]().Kind()) {
+		buf := bytes.NewBuffer([]byte{binaryVersion, binaryGob})
+		if err := gob.NewEncoder(buf).Encode(elements); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	b := []byte{binaryVersion, binaryCompact}
+	b = binary.AppendUvarint(b, uint64(len(elements)))
+	for i := range elements {
+		v := reflect.ValueOf(&elements[i]).Elem()
+		switch v.Kind() { //nolint:exhaustive
+		case reflect.Bool:
+			if v.Bool() {
+				b = append(b, 1)
+			} else {
+				b = append(b, 0)
+			}
+		case reflect.String:
+			b = binary.AppendUvarint(b, uint64(v.Len()))
+			b = append(b, v.String()...)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b = binary.AppendVarint(b, v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			b = binary.AppendUvarint(b, v.Uint())
+		case reflect.Float32:
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float())))
+		case reflect.Float64:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+		}
+	}
+	return b, nil
+}
+
+// unmarshalBinaryElements decodes elements from the given binary form.
+func unmarshalBinaryElements[E comparable](data []byte) ([]E, error) {
+	if len(data) < 2 {
+		return nil, ErrMalformedBinary
+	}
+	if data[0] != binaryVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBinaryVersion, data[0])
+	}
+	switch data[1] {
+	case binaryCompact:
+		if !compactKind(reflect.TypeFor[E]().Kind()) {
+			return nil, ErrMalformedBinary
+		}
+		return unmarshalCompactElements[E](data[2:])
+	case binaryGob:
+		var elements []E
+		if err := gob.NewDecoder(bytes.NewReader(data[2:])).Decode(&elements); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBinary, err)
+		}
+		return elements, nil
+	default:
+		return nil, ErrMalformedBinary
+	}
+}
+
+func unmarshalCompactElements[E comparable](data []byte) ([]E, error) {
+	n, read := binary.Uvarint(data)
+	if read <= 0 || n > uint64(len(data)) {
+		return nil, ErrMalformedBinary
+	}
+	data = data[read:]
+
+	elements := make([]E, n)
+	for i := range elements {
+		v := reflect.ValueOf(&elements[i]).Elem()
+		switch v.Kind() { //nolint:exhaustive
+		case reflect.Bool:
+			if len(data) < 1 || data[0] > 1 {
+				return nil, ErrMalformedBinary
+			}
+			v.SetBool(data[0] == 1)
+			data = data[1:]
+		case reflect.String:
+			l, read := binary.Uvarint(data)
+			if read <= 0 || l > uint64(len(data)-read) {
+				return nil, ErrMalformedBinary
+			}
+			v.SetString(string(data[read : read+int(l)]))
+			data = data[read+int(l):]
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			x, read := binary.Varint(data)
+			if read <= 0 || v.OverflowInt(x) {
+				return nil, ErrMalformedBinary
+			}
+			v.SetInt(x)
+			data = data[read:]
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			x, read := binary.Uvarint(data)
+			if read <= 0 || v.OverflowUint(x) {
+				return nil, ErrMalformedBinary
+			}
+			v.SetUint(x)
+			data = data[read:]
+		case reflect.Float32:
+			if len(data) < 4 {
+				return nil, ErrMalformedBinary
+			}
+			v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+			data = data[4:]
+		case reflect.Float64:
+			if len(data) < 8 {
+				return nil, ErrMalformedBinary
+			}
+			v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+			data = data[8:]
+		}
+	}
+	if len(data) > 0 {
+		return nil, ErrMalformedBinary
+	}
+	return elements, nil
+}
+
+// unmarshalBinaryInto replaces the elements of the given collection with the elements decoded from the binary form.
+func unmarshalBinaryInto[E comparable](c Collection[E], data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	c.Clear()
+	c.Add(elements...)
+	return nil
+}
+
+// marshalBinaryEntries encodes the keys and the values of entries into a binary form,
+// which is the version byte, the length of the binary form of the keys,
+// and the binary forms of the keys and the values.
+func marshalBinaryEntries[K comparable, V comparable](keys []K, values []V) ([]byte, error) {
+	k, err := marshalBinaryElements(keys)
+	if err != nil {
+		return nil, err
+	}
+	v, err := marshalBinaryElements(values)
+	if err != nil {
+		return nil, err
+	}
+	b := binary.AppendUvarint([]byte{binaryVersion}, uint64(len(k)))
+	b = append(b, k...)
+	return append(b, v...), nil
+}
+
+// unmarshalBinaryEntries decodes the keys and the values of entries from the given binary form.
+func unmarshalBinaryEntries[K comparable, V comparable](data []byte) ([]K, []V, error) {
+	if len(data) < 1 {
+		return nil, nil, ErrMalformedBinary
+	}
+	if data[0] != binaryVersion {
+		return nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedBinaryVersion, data[0])
+	}
+	n, read := binary.Uvarint(data[1:])
+	if read <= 0 || n > uint64(len(data)-1-read) {
+		return nil, nil, ErrMalformedBinary
+	}
+	data = data[1+read:]
+	keys, err := unmarshalBinaryElements[K](data[:n])
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := unmarshalBinaryElements[V](data[n:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(keys) != len(values) {
+		return nil, nil, ErrMalformedBinary
+	}
+	return keys, values, nil
+}
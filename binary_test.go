@@ -0,0 +1,293 @@
+package kol
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+		empty      Collection[int]
+		want       []int
+	}{
+		{name: "list", collection: NewList(3, -1, 3), empty: NewList(9), want: []int{3, -1, 3}},
+		{name: "empty list", collection: NewList[int](), empty: NewList(9), want: []int{}},
+		{name: "set", collection: NewSet(3, 1), empty: NewSet(9), want: []int{1, 3}},
+		{name: "deque", collection: NewDeque(1, 2), empty: NewDeque(9), want: []int{1, 2}},
+		{name: "ring buffer", collection: NewDeque(1, 2, 3), empty: NewRingBuffer[int](2, OverflowOverwrite), want: []int{2, 3}},
+		{name: "multiset", collection: NewMultiset(2, 2, 1), empty: NewMultiset(9), want: []int{1, 2, 2}},
+		{name: "priority queue", collection: NewPriorityQueue(2, 1), empty: NewPriorityQueue(9), want: []int{1, 2}},
+		{name: "blocking queue", collection: newBlockingQueueOf(2, 1), empty: NewBlockingQueue[int](2), want: []int{2, 1}},
+		{name: "concurrent list", collection: NewConcurrentList(2, 1), empty: NewConcurrentList(9), want: []int{2, 1}},
+		{name: "concurrent set", collection: NewConcurrentSet(2, 1), empty: NewConcurrentSet(9), want: []int{1, 2}},
+		{name: "synchronized list", collection: SynchronizedList(NewList(2, 1)), empty: SynchronizedList(NewList(9)), want: []int{2, 1}},
+		{name: "synchronized set", collection: SynchronizedSet(NewSet(2, 1)), empty: SynchronizedSet(NewSet(9)), want: []int{1, 2}},
+		{name: "expiring set", collection: NewExpiringSet(time.Hour, nil, 2, 1), empty: NewExpiringSet(time.Hour, nil, 9), want: []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.collection.(encoding.BinaryMarshaler).MarshalBinary()
+			assert.NoError(t, err)
+			assert.Equal(t, binaryVersion, data[0])
+			assert.NoError(t, tt.empty.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+			if _, ok := tt.empty.(List[int]); ok {
+				assert.Equal(t, tt.want, tt.empty.ToSlice())
+			} else {
+				assert.ElementsMatch(t, tt.want, tt.empty.ToSlice())
+			}
+		})
+	}
+}
+
+func newBlockingQueueOf(elements ...int) Collection[int] {
+	q := NewBlockingQueue[int](len(elements))
+	q.Add(elements...)
+	return q
+}
+
+func TestMarshalBinary_Compact(t *testing.T) {
+	data, err := NewList[int8](1, -1).(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{binaryVersion, binaryCompact, 2, 2, 1}, data)
+
+	data, err = NewList("ab").(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{binaryVersion, binaryCompact, 1, 2, 'a', 'b'}, data)
+}
+
+func TestMarshalBinary_Kinds(t *testing.T) {
+	type celsius float32
+	assertRoundTrip(t, NewList(true, false))
+	assertRoundTrip(t, NewList[uint16](0, math.MaxUint16))
+	assertRoundTrip(t, NewList[int64](math.MinInt64, math.MaxInt64))
+	assertRoundTrip(t, NewList[celsius](-1.5, 36.6))
+	assertRoundTrip(t, NewList(math.Inf(1), math.SmallestNonzeroFloat64))
+	assertRoundTrip(t, NewList("", "kol", "日本"))
+	assertRoundTrip(t, NewList(NewPair("a", 1), NewPair("b", 2)))
+}
+
+func assertRoundTrip[E comparable](t *testing.T, l List[E]) {
+	t.Helper()
+	data, err := l.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	got := NewList[E]()
+	assert.NoError(t, got.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	assert.Equal(t, l.ToSlice(), got.ToSlice())
+}
+
+func TestUnmarshalBinary_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "empty", data: nil, want: ErrMalformedBinary},
+		{name: "version", data: []byte{binaryVersion + 1, binaryCompact, 0}, want: ErrUnsupportedBinaryVersion},
+		{name: "format", data: []byte{binaryVersion, 9, 0}, want: ErrMalformedBinary},
+		{name: "count", data: []byte{binaryVersion, binaryCompact, 2, 2}, want: ErrMalformedBinary},
+		{name: "overflow", data: []byte{binaryVersion, binaryCompact, 1, 0x80, 0x02}, want: ErrMalformedBinary},
+		{name: "trailing", data: []byte{binaryVersion, binaryCompact, 1, 2, 2}, want: ErrMalformedBinary},
+		{name: "gob", data: []byte{binaryVersion, binaryGob, 1}, want: ErrMalformedBinary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList[int8](1)
+			assert.ErrorIs(t, l.(encoding.BinaryUnmarshaler).UnmarshalBinary(tt.data), tt.want)
+			assert.Equal(t, []int8{1}, l.ToSlice())
+		})
+	}
+}
+
+func TestMarshalBinary_Maps(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1), NewPair("b", 2)))
+	data, err := b.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, binaryVersion, data[0])
+	decoded := NewBiMap[string, int]()
+	inverse := decoded.Inverse()
+	assert.NoError(t, decoded.Put("z", 9))
+	assert.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	assert.ElementsMatch(t, b.Entries().ToSlice(), decoded.Entries().ToSlice())
+	k, _ := inverse.GetByKey(2)
+	assert.Equal(t, "b", k)
+
+	lm := NewListMultimap[string, int]()
+	lm.PutAll("a", 3, 1, 3)
+	lm.Put("b", 2)
+	data, err = lm.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	decodedList := NewListMultimap[string, int]()
+	decodedList.Put("z", 9)
+	assert.NoError(t, decodedList.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	assert.Equal(t, []int{3, 1, 3}, decodedList.Get("a").ToSlice())
+	assert.Equal(t, 4, decodedList.Size())
+
+	sm := NewSetMultimap[int, Pair[string, int]]()
+	sm.PutAll(1, NewPair("x", 1), NewPair("y", 2))
+	data, err = sm.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	decodedSet := NewSetMultimap[int, Pair[string, int]]()
+	assert.NoError(t, decodedSet.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	assert.True(t, decodedSet.Get(1).Equals(sm.Get(1)))
+}
+
+func TestUnmarshalBinary_MapsError(t *testing.T) {
+	keys, err := NewList("a", "b").(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	entries := func(values ...int) []byte {
+		v, err := NewList(values...).(encoding.BinaryMarshaler).MarshalBinary()
+		assert.NoError(t, err)
+		return append(append([]byte{binaryVersion, byte(len(keys))}, keys...), v...)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "empty", data: nil, want: ErrMalformedBinary},
+		{name: "version", data: []byte{binaryVersion + 1, 0}, want: ErrUnsupportedBinaryVersion},
+		{name: "length", data: []byte{binaryVersion, 9, binaryVersion}, want: ErrMalformedBinary},
+		{name: "count", data: entries(1), want: ErrMalformedBinary},
+		{name: "duplicate value", data: entries(1, 1), want: ErrDuplicateValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := NewBiMapFromPairs(NewList(NewPair("z", 9)))
+			assert.ErrorIs(t, b.(encoding.BinaryUnmarshaler).UnmarshalBinary(tt.data), tt.want)
+			assert.Equal(t, []Pair[string, int]{NewPair("z", 9)}, b.Entries().ToSlice())
+		})
+	}
+}
+
+func TestUnmarshalBinary_BlockingQueueFull(t *testing.T) {
+	data, err := NewList(1, 2, 3).(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	q := NewBlockingQueue[int](2)
+	assert.ErrorIs(t, q.(encoding.BinaryUnmarshaler).UnmarshalBinary(data), ErrQueueFull)
+}
+
+func TestGob(t *testing.T) {
+	RegisterGob[string]()
+
+	type dto struct {
+		Tags  Set[string]
+		Names List[string]
+	}
+	var buf bytes.Buffer
+	in := dto{Tags: NewSet("a", "b"), Names: NewList("x", "y")}
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out dto
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.ElementsMatch(t, []string{"a", "b"}, out.Tags.ToSlice())
+	assert.Equal(t, []string{"x", "y"}, out.Names.ToSlice())
+}
+
+func TestGob_Maps(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("a", 1)))
+	lm := NewListMultimap[string, int]()
+	lm.PutAll("a", 2, 1)
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	assert.NoError(t, enc.Encode(b))
+	assert.NoError(t, enc.Encode(lm))
+
+	dec := gob.NewDecoder(&buf)
+	outMap := NewBiMap[string, int]()
+	assert.NoError(t, dec.Decode(outMap))
+	assert.Equal(t, []Pair[string, int]{NewPair("a", 1)}, outMap.Entries().ToSlice())
+	outMultimap := NewListMultimap[string, int]()
+	assert.NoError(t, dec.Decode(outMultimap))
+	assert.Equal(t, []int{2, 1}, outMultimap.Get("a").ToSlice())
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	f.Add([]byte{binaryVersion, binaryCompact, 2, 2, 1})
+	f.Add([]byte{binaryVersion, binaryCompact, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+	f.Add([]byte{binaryVersion, binaryGob, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		l := NewList[int16]()
+		if err := l.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+			return
+		}
+		assertRoundTrip(t, l)
+	})
+}
+
+func FuzzMarshalBinary(f *testing.F) {
+	f.Add("a", "", int64(0), int64(-1), 1.5)
+	f.Add("日本", "kol", int64(math.MaxInt64), int64(math.MinInt64), math.Inf(-1))
+	f.Fuzz(func(t *testing.T, s1, s2 string, i1, i2 int64, x float64) {
+		assertRoundTrip(t, NewList(s1, s2, s1))
+		assertRoundTrip(t, NewList(i1, i2))
+		if !math.IsNaN(x) {
+			assertRoundTrip(t, NewList(x))
+		}
+
+		data, err := NewSet(s1, s2).(encoding.BinaryMarshaler).MarshalBinary()
+		assert.NoError(t, err)
+		s := NewSet[string]()
+		assert.NoError(t, s.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+		assert.True(t, s.Equals(NewSet(s1, s2)))
+	})
+}
+
+func FuzzUnmarshalBinary_Maps(f *testing.F) {
+	f.Add([]byte{binaryVersion, 5, binaryVersion, binaryCompact, 1, 1, 'a', binaryVersion, binaryCompact, 1, 2})
+	f.Add([]byte{binaryVersion, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := NewBiMap[string, int16]()
+		if err := b.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err == nil {
+			again, err := b.(encoding.BinaryMarshaler).MarshalBinary()
+			assert.NoError(t, err)
+			decoded := NewBiMap[string, int16]()
+			assert.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(again))
+			assert.ElementsMatch(t, b.Entries().ToSlice(), decoded.Entries().ToSlice())
+		}
+
+		mm := NewListMultimap[string, int16]()
+		if err := mm.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err == nil {
+			assertMultimapRoundTrip(t, mm)
+		}
+	})
+}
+
+func FuzzMarshalBinary_Maps(f *testing.F) {
+	f.Add("a", "", int64(0), int64(-1))
+	f.Add("日本", "kol", int64(math.MaxInt64), int64(math.MinInt64))
+	f.Fuzz(func(t *testing.T, k1, k2 string, v1, v2 int64) {
+		b := NewBiMap[string, int64]()
+		b.ForcePut(k1, v1)
+		b.ForcePut(k2, v2)
+		data, err := b.(encoding.BinaryMarshaler).MarshalBinary()
+		assert.NoError(t, err)
+		decoded := NewBiMap[string, int64]()
+		assert.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+		assert.ElementsMatch(t, b.Entries().ToSlice(), decoded.Entries().ToSlice())
+
+		mm := NewListMultimap[string, int64]()
+		mm.PutAll(k1, v1, v2, v1)
+		mm.Put(k2, v2)
+		assertMultimapRoundTrip(t, mm)
+	})
+}
+
+func assertMultimapRoundTrip[K comparable, V comparable](t *testing.T, mm ListMultimap[K, V]) {
+	t.Helper()
+	data, err := mm.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	got := NewListMultimap[K, V]()
+	assert.NoError(t, got.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+	assert.Equal(t, mm.Size(), got.Size())
+	mm.KeySet().ForEach(func(k K) {
+		assert.Equal(t, mm.Get(k).ToSlice(), got.Get(k).ToSlice())
+	})
+}
@@ -0,0 +1,505 @@
+package kol
+
+import (
+	"context"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueClosed is returned when putting an element into a closed queue,
+	// or taking an element from a closed and empty queue.
+	ErrQueueClosed = errors.New("kol: queue closed")
+	// ErrQueueFull is returned when decoding more elements than the capacity into a queue,
+	// and is the panic value of adding an element to a full queue by Add.
+	ErrQueueFull = errors.New("kol: queue full")
+)
+
+// BlockingQueue is a bounded FIFO queue safe for concurrent use,
+// which blocks producers while it is full and consumers while it is empty.
+//
+// Add of Collection cannot report failures, so it panics with ErrQueueFull if there is not enough space,
+// or with ErrQueueClosed if the queue is closed. Producers should insert elements by Put or Offer instead,
+// e.g. Offer with a zero timeout to insert an element without blocking.
+type BlockingQueue[E comparable] interface {
+	Collection[E]
+
+	// Close closes this queue. Blocked producers and consumers are woken up.
+	// Elements already in the queue can still be taken. Closing a closed queue does nothing.
+	Close()
+	// Consume returns a sequence that takes elements from this queue, blocking until each element is available.
+	// The sequence ends once this queue is closed and drained, and then its Err returns ErrQueueClosed.
+	Consume() Sequence[E]
+	// ConsumeCtx is like Consume, but the sequence also ends once the given context is done,
+	// and then its Err returns the error of the context.
+	ConsumeCtx(ctx context.Context) Sequence[E]
+	// DrainTo removes at most maxElements available elements without blocking and adds them to the given list.
+	// If maxElements is negative, it removes all available elements. It returns the number of removed elements.
+	DrainTo(list List[E], maxElements int) int
+	// Offer inserts the given element, waiting up to the given timeout for space to become available.
+	// It returns `false` if the timeout elapses or this queue is closed.
+	Offer(element E, timeout time.Duration) bool
+	// Poll removes and returns the head of this queue, waiting up to the given timeout for an element to become available.
+	// If the timeout elapses or this queue is closed and empty, it returns `false` as a second return value.
+	Poll(timeout time.Duration) (E, bool)
+	// Put inserts the given element, blocking until space becomes available.
+	// It returns ErrQueueClosed if this queue is closed.
+	Put(element E) error
+	// RemainingCapacity returns the number of elements that can be inserted without blocking.
+	RemainingCapacity() int
+	// Take removes and returns the head of this queue, blocking until an element becomes available.
+	// It returns ErrQueueClosed if this queue is closed and empty.
+	Take() (E, error)
+	// TakeCtx is like Take, but stops blocking and returns the error of the given context once it is done.
+	TakeCtx(ctx context.Context) (E, error)
+}
+
+type blockingQueue[E comparable] struct {
+	mu       sync.Mutex
+	items    *deque[E]
+	capacity int
+	closed   bool
+	changed  chan struct{} // closed and replaced on every change to wake up waiters
+}
+
+var _ BlockingQueue[int] = (*blockingQueue[int])(nil)
+
+// NewBlockingQueue returns an empty BlockingQueue that holds at most capacity elements.
+// It panics if capacity is not positive.
+func NewBlockingQueue[E comparable](capacity int) BlockingQueue[E] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("kol: non-positive capacity of BlockingQueue: %d", capacity))
+	}
+	return &blockingQueue[E]{
+		items:    NewDeque[E]().(*deque[E]), //nolint:forcetypeassert
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+}
+
+// signal wakes up all waiters. The caller must hold mu.
+func (q *blockingQueue[E]) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// await releases mu and waits until this queue changes or the given context is done.
+// It reacquires mu before returning.
+func (q *blockingQueue[E]) await(ctx context.Context) error {
+	changed := q.changed
+	q.mu.Unlock()
+	defer q.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *blockingQueue[E]) put(ctx context.Context, e E) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.closed {
+			return ErrQueueClosed
+		}
+		if q.items.Size() < q.capacity {
+			break
+		}
+		if err := q.await(ctx); err != nil {
+			return err
+		}
+	}
+	q.items.AddLast(e)
+	q.signal()
+	return nil
+}
+
+func (q *blockingQueue[E]) take(ctx context.Context) (E, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.items.IsEmpty() {
+		if q.closed {
+			var zero E
+			return zero, ErrQueueClosed
+		}
+		if err := q.await(ctx); err != nil {
+			var zero E
+			return zero, err
+		}
+	}
+	e, _ := q.items.RemoveFirst()
+	q.signal()
+	return e, nil
+}
+
+func (q *blockingQueue[E]) snapshot() *list[E] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.toList()
+}
+
+func (q *blockingQueue[E]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.signal()
+}
+
+func (q *blockingQueue[E]) Consume() Sequence[E] {
+	return q.ConsumeCtx(context.Background())
+}
+
+func (q *blockingQueue[E]) ConsumeCtx(ctx context.Context) Sequence[E] {
+	return newSequence[E](newConsumeSequence[E](ctx, q))
+}
+
+func (q *blockingQueue[E]) DrainTo(l List[E], maxElements int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := q.items.Size()
+	if maxElements >= 0 {
+		n = min(n, maxElements)
+	}
+	if n == 0 {
+		return 0
+	}
+	for range n {
+		e, _ := q.items.RemoveFirst()
+		l.Add(e)
+	}
+	q.signal()
+	return n
+}
+
+func (q *blockingQueue[E]) Offer(e E, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return q.put(ctx, e) == nil
+}
+
+func (q *blockingQueue[E]) Poll(timeout time.Duration) (E, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	e, err := q.take(ctx)
+	return e, err == nil
+}
+
+func (q *blockingQueue[E]) Put(e E) error {
+	return q.put(context.Background(), e)
+}
+
+func (q *blockingQueue[E]) RemainingCapacity() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.capacity - q.items.Size()
+}
+
+func (q *blockingQueue[E]) Take() (E, error) {
+	return q.take(context.Background())
+}
+
+func (q *blockingQueue[E]) TakeCtx(ctx context.Context) (E, error) {
+	return q.take(ctx)
+}
+
+var _ Collection[int] = (*blockingQueue[int])(nil)
+
+// Add inserts the given elements without blocking.
+// It panics with ErrQueueFull if there is not enough space, or with ErrQueueClosed if this queue is closed.
+func (q *blockingQueue[E]) Add(elements ...E) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		panic(ErrQueueClosed)
+	}
+	if q.items.Size()+len(elements) > q.capacity {
+		panic(ErrQueueFull)
+	}
+	if len(elements) == 0 {
+		return
+	}
+	q.items.Add(elements...)
+	q.signal()
+}
+
+func (q *blockingQueue[E]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Clear()
+	q.signal()
+}
+
+func (q *blockingQueue[E]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+func (q *blockingQueue[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(q.ToSlice(), func(e E) {
+		q.Remove(e)
+	}, nil)
+}
+
+func (q *blockingQueue[E]) Remove(targets ...E) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Remove(targets...)
+	q.signal()
+}
+
+func (q *blockingQueue[E]) Retain(targets ...E) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Retain(targets...)
+	q.signal()
+}
+
+func (q *blockingQueue[E]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Size()
+}
+
+var _ Iterable[int] = (*blockingQueue[int])(nil)
+
+func (q *blockingQueue[E]) All(p func(e E) bool) bool {
+	return q.snapshot().All(p)
+}
+
+func (q *blockingQueue[E]) Any(p func(e E) bool) bool {
+	return q.snapshot().Any(p)
+}
+
+// AsSequence returns a sequence over a snapshot of this queue, which does not remove elements.
+// Use Consume to take elements from this queue.
+func (q *blockingQueue[E]) AsSequence() Sequence[E] {
+	return q.snapshot().AsSequence()
+}
+
+func (q *blockingQueue[E]) Contains(e E) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Contains(e)
+}
+
+func (q *blockingQueue[E]) ContainsAll(elements ...E) bool {
+	return q.snapshot().ContainsAll(elements...)
+}
+
+func (q *blockingQueue[E]) ContainsAny(elements ...E) bool {
+	return q.snapshot().ContainsAny(elements...)
+}
+
+func (q *blockingQueue[E]) Count(p func(e E) bool) int {
+	return q.snapshot().Count(p)
+}
+
+func (q *blockingQueue[E]) Distinct() Collection[E] {
+	return q.snapshot().Distinct()
+}
+
+func (q *blockingQueue[E]) Filter(p func(e E) bool) Collection[E] {
+	return q.snapshot().Filter(p)
+}
+
+func (q *blockingQueue[E]) Find(p func(e E) bool) (E, bool) {
+	return q.snapshot().Find(p)
+}
+
+func (q *blockingQueue[E]) ForEach(a func(e E)) {
+	q.snapshot().ForEach(a)
+}
+
+func (q *blockingQueue[E]) Intersect(other Iterable[E]) Set[E] {
+	return q.snapshot().Intersect(other)
+}
+
+func (q *blockingQueue[E]) IsDisjointWith(other Iterable[E]) bool {
+	return q.snapshot().IsDisjointWith(other)
+}
+
+func (q *blockingQueue[E]) IsSubsetOf(other Iterable[E]) bool {
+	return q.snapshot().IsSubsetOf(other)
+}
+
+func (q *blockingQueue[E]) IsSupersetOf(other Iterable[E]) bool {
+	return q.snapshot().IsSupersetOf(other)
+}
+
+func (q *blockingQueue[E]) Iterator() Iterator[E] {
+	return q.MutableIterator()
+}
+
+func (q *blockingQueue[E]) Map(t func(e E) E) Collection[E] {
+	return q.snapshot().Map(t)
+}
+
+func (q *blockingQueue[E]) Minus(e ...E) Collection[E] {
+	return q.snapshot().Minus(e...)
+}
+
+func (q *blockingQueue[E]) None(p func(e E) bool) bool {
+	return q.snapshot().None(p)
+}
+
+func (q *blockingQueue[E]) Plus(e ...E) Collection[E] {
+	return q.snapshot().Plus(e...)
+}
+
+func (q *blockingQueue[E]) Single(p func(e E) bool) (E, bool) {
+	return q.snapshot().Single(p)
+}
+
+func (q *blockingQueue[E]) Subtract(other Iterable[E]) Set[E] {
+	return q.snapshot().Subtract(other)
+}
+
+func (q *blockingQueue[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return q.snapshot().SymmetricDifference(other)
+}
+
+func (q *blockingQueue[E]) ToList() List[E] {
+	return q.snapshot()
+}
+
+func (q *blockingQueue[E]) ToSet() Set[E] {
+	return q.snapshot().ToSet()
+}
+
+func (q *blockingQueue[E]) ToSlice() []E {
+	return q.snapshot().elements
+}
+
+func (q *blockingQueue[E]) Union(other Iterable[E]) Set[E] {
+	return q.snapshot().Union(other)
+}
+
+type consumeSequence[E comparable] struct {
+	ctx   context.Context //nolint:containedctx
+	queue *blockingQueue[E]
+	err   error
+}
+
+var _ seq[int] = (*consumeSequence[int])(nil)
+
+func newConsumeSequence[E comparable](ctx context.Context, queue *blockingQueue[E]) seq[E] {
+	return &consumeSequence[E]{ctx: ctx, queue: queue}
+}
+
+func (s *consumeSequence[E]) Next() (E, bool) {
+	if s.err != nil {
+		var zero E
+		return zero, false
+	}
+	e, err := s.queue.take(s.ctx)
+	if err != nil {
+		s.err = err
+		return e, false
+	}
+	return e, true
+}
+
+// Err returns the reason the sequence ended, i.e. ErrQueueClosed or the error of the context.
+func (s *consumeSequence[E]) Err() error {
+	return s.err
+}
+
+func (s *consumeSequence[E]) String() string {
+	return fmt.Sprintf("consume: %v", s.queue.ToSlice())
+}
+
+// replaceAll replaces the elements of this queue with the given elements.
+func (q *blockingQueue[E]) replaceAll(elements []E) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if len(elements) > q.capacity {
+		return ErrQueueFull
+	}
+	q.items.Clear()
+	q.items.Add(elements...)
+	q.signal()
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*blockingQueue[int])(nil)
+	_ json.Unmarshaler = (*blockingQueue[int])(nil)
+)
+
+// MarshalJSON encodes a snapshot of this queue as a JSON array from the head.
+func (q *blockingQueue[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of this queue with the decoded JSON array.
+// It returns ErrQueueFull if the array has more elements than the capacity, or ErrQueueClosed if this queue is closed.
+func (q *blockingQueue[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return q.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*blockingQueue[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*blockingQueue[int])(nil)
+	_ gob.GobEncoder             = (*blockingQueue[int])(nil)
+	_ gob.GobDecoder             = (*blockingQueue[int])(nil)
+)
+
+// MarshalBinary encodes a snapshot of this queue into a compact binary form from the head.
+func (q *blockingQueue[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(q.ToSlice())
+}
+
+// UnmarshalBinary replaces the elements of this queue with the decoded binary form.
+// It returns ErrQueueFull if it has more elements than the capacity, or ErrQueueClosed if this queue is closed.
+func (q *blockingQueue[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return q.replaceAll(elements)
+}
+
+// GobEncode encodes this queue by MarshalBinary.
+func (q *blockingQueue[E]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode decodes this queue by UnmarshalBinary.
+func (q *blockingQueue[E]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*blockingQueue[int])(nil)
+	_ fmt.Stringer   = (*blockingQueue[int])(nil)
+	_ slog.LogValuer = (*blockingQueue[int])(nil)
+)
+
+func (q *blockingQueue[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "BlockingQueue", q.ToSlice())
+}
+
+func (q *blockingQueue[E]) String() string {
+	return fmt.Sprint(q)
+}
+
+func (q *blockingQueue[E]) LogValue() slog.Value {
+	return logCollection("BlockingQueue", q.ToSlice())
+}
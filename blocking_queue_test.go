@@ -0,0 +1,185 @@
+package kol
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBlockingQueue(t *testing.T) {
+	assert.Panics(t, func() {
+		NewBlockingQueue[int](0)
+	})
+}
+
+func TestBlockingQueue_PutAndTake(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	const n = 100
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range n {
+			assert.NoError(t, q.Put(i))
+		}
+		q.Close()
+	}()
+
+	got := make([]int, 0, n)
+	for {
+		e, err := q.Take()
+		if err != nil {
+			assert.ErrorIs(t, err, ErrQueueClosed)
+			break
+		}
+		got = append(got, e)
+	}
+	wg.Wait()
+
+	want := make([]int, n)
+	for i := range want {
+		want[i] = i
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestBlockingQueue_OfferAndPoll(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	assert.True(t, q.Offer(1, 0))
+	assert.False(t, q.Offer(2, time.Millisecond))
+	assert.Equal(t, 0, q.RemainingCapacity())
+
+	e, ok := q.Poll(0)
+	assert.Equal(t, 1, e)
+	assert.True(t, ok)
+
+	e, ok = q.Poll(time.Millisecond)
+	assert.Equal(t, 0, e)
+	assert.False(t, ok)
+}
+
+func TestBlockingQueue_TakeCtx(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	_, err := q.TakeCtx(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	assert.NoError(t, q.Put(1))
+
+	done := make(chan error)
+	go func() {
+		done <- q.Put(2)
+	}()
+	q.Close()
+	q.Close()
+	assert.ErrorIs(t, <-done, ErrQueueClosed)
+
+	e, err := q.Take()
+	assert.Equal(t, 1, e)
+	assert.NoError(t, err)
+
+	_, err = q.Take()
+	assert.ErrorIs(t, err, ErrQueueClosed)
+	assert.False(t, q.Offer(3, 0))
+	assert.PanicsWithValue(t, ErrQueueClosed, func() {
+		q.Add(3)
+	})
+}
+
+func TestBlockingQueue_DrainTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		elements    []int
+		maxElements int
+		want        []int
+		wantRest    []int
+	}{
+		{
+			name:        "drain some elements",
+			elements:    []int{1, 2, 3},
+			maxElements: 2,
+			want:        []int{1, 2},
+			wantRest:    []int{3},
+		},
+		{
+			name:        "drain all elements",
+			elements:    []int{1, 2, 3},
+			maxElements: -1,
+			want:        []int{1, 2, 3},
+			wantRest:    []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewBlockingQueue[int](10)
+			q.Add(tt.elements...)
+			l := NewList[int]()
+			assert.Equal(t, len(tt.want), q.DrainTo(l, tt.maxElements))
+			assert.Equal(t, tt.want, l.ToSlice())
+			assert.Equal(t, tt.wantRest, q.ToSlice())
+		})
+	}
+}
+
+func TestBlockingQueue_Consume(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	go func() {
+		for i := range 10 {
+			_ = q.Put(i)
+		}
+		q.Close()
+	}()
+
+	got := q.Consume().
+		Filter(func(e int) bool { return e%2 == 0 }).
+		Map(func(e int) int { return e * 10 }).
+		ToSlice()
+	assert.Equal(t, []int{0, 20, 40, 60, 80}, got)
+}
+
+func TestBlockingQueue_ConsumeErr(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	assert.NoError(t, q.Put(1))
+	q.Close()
+	s := q.Consume()
+	assert.Equal(t, []int{1}, s.ToSlice())
+	assert.ErrorIs(t, s.Err(), ErrQueueClosed)
+
+	q = NewBlockingQueue[int](2)
+	assert.NoError(t, q.Put(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s = q.ConsumeCtx(ctx).Map(func(e int) int { return e * 10 })
+	assert.Equal(t, []int{10}, s.ToSlice())
+	assert.ErrorIs(t, s.Err(), context.DeadlineExceeded)
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestBlockingQueue_Collection(t *testing.T) {
+	q := NewBlockingQueue[int](3)
+	q.Add(1, 2, 3)
+	assert.PanicsWithValue(t, ErrQueueFull, func() {
+		q.Add(4)
+	})
+	assert.False(t, q.Offer(4, 0))
+	assert.True(t, q.Contains(2))
+	assert.Equal(t, NewList(2), q.Filter(func(e int) bool { return e%2 == 0 }))
+	assert.Equal(t, []int{1, 2, 3}, q.AsSequence().ToSlice())
+	assert.Equal(t, 3, q.Size())
+
+	q.Remove(2)
+	assert.Equal(t, []int{1, 3}, q.ToSlice())
+	assert.True(t, q.Offer(4, 0))
+
+	q.Clear()
+	assert.True(t, q.IsEmpty())
+}
@@ -0,0 +1,464 @@
+package kol
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// EvictionPolicy decides which entry a Cache evicts when it is full.
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used entry.
+	EvictLRU EvictionPolicy = iota
+	// EvictLFU evicts the least frequently used entry, or the least recently used one among them.
+	EvictLFU
+	// EvictFIFO evicts the oldest inserted entry.
+	EvictFIFO
+)
+
+// EvictionReason tells why an entry has been evicted from a Cache.
+type EvictionReason int
+
+const (
+	// EvictedByCapacity means the entry has been evicted to make room for another entry.
+	EvictedByCapacity EvictionReason = iota
+	// EvictedByExpiry means the time-to-live of the entry has elapsed.
+	EvictedByExpiry
+)
+
+// CacheConfig configures a Cache created by NewCache.
+type CacheConfig[K comparable, V any] struct {
+	// Capacity is the maximum number of entries. Zero means unbounded.
+	Capacity int
+	// Policy decides which entry is evicted when the cache is full.
+	Policy EvictionPolicy
+	// TTL is the time-to-live of an entry since it has been put. Zero means entries never expire.
+	TTL time.Duration
+	// Clock provides the current time to expire entries. Nil means SystemClock.
+	Clock Clock
+	// OnEvict is called with each evicted entry. It is not called for entries removed by Remove or Clear.
+	OnEvict func(key K, value V, reason EvictionReason)
+}
+
+// CacheStats is statistics of lookups and evictions of a Cache.
+type CacheStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+// HitRate returns the ratio of hits to all lookups, or zero if there has been no lookup.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Cache is a bounded associative collection that evicts entries according to its EvictionPolicy and time-to-live.
+// Expired entries are removed lazily when they are looked up by Get or Put, or eagerly by EvictExpired.
+type Cache[K comparable, V any] interface {
+	// Clear removes all entries.
+	Clear()
+	// Contains returns `true` if the given key is cached and not expired.
+	// It has no side effect: it neither counts as a use of the entry, evicts an expired entry, nor updates statistics.
+	Contains(key K) bool
+	// EvictExpired evicts all expired entries and returns the number of evicted entries.
+	EvictExpired() int
+	// Format implements fmt.Formatter. A cache is formatted as `{k1: v1, k2: v2}` in a deterministic sorted order of keys,
+	// omitting expired entries. Like Peek, it has no side effect.
+	// %+v prefixes the entries with the type and the size,
+	// and %.Nv formats only the first N keys followed by "...and M more".
+	Format(f fmt.State, verb rune)
+	// Get returns the value of the given key and counts it as a use of the entry.
+	// If the key is not cached or expired, it returns `false` as a second return value.
+	Get(key K) (V, bool)
+	// GetOrLoad returns the value of the given key, or calls the given load function and caches its result on a miss.
+	// If the load function returns an error, nothing is cached and the error is returned.
+	GetOrLoad(key K, load func(key K) (V, error)) (V, error)
+	// Keys returns a set of the cached keys at the moment.
+	Keys() Set[K]
+	// LogValue implements slog.LogValuer, and returns a group of the type, the size
+	// and a sample of the entries limited by SetLogSampleSize, in the same order as Format.
+	// Like Format, it omits expired entries and has no side effect.
+	LogValue() slog.Value
+	// Peek returns the value of the given key like Get, but it has no side effect:
+	// it neither counts as a use of the entry, evicts an expired entry, nor updates statistics.
+	Peek(key K) (V, bool)
+	// Put caches the given value for the given key, evicting an entry if this cache is full.
+	Put(key K, value V)
+	// Remove removes the entry of the given key and returns `true` if it has been cached.
+	Remove(key K) bool
+	// Size returns the number of cached entries.
+	Size() int
+	// Stats returns statistics of this cache.
+	Stats() CacheStats
+	// String returns the entries formatted by %v.
+	String() string
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+	inserted  int
+	accessed  int
+	frequency int
+	handle    *PriorityQueueHandle[*cacheEntry[K, V]]
+}
+
+type cache[K comparable, V any] struct {
+	config  CacheConfig[K, V]
+	entries map[K]*cacheEntry[K, V]
+	victims *priorityQueue[*cacheEntry[K, V]] // ordered from the next entry to evict
+	tick    int
+	stats   CacheStats
+}
+
+var _ Cache[int, string] = (*cache[int, string])(nil)
+
+// NewCache returns an empty Cache configured by the given config.
+// It panics if the capacity is negative.
+func NewCache[K comparable, V any](config CacheConfig[K, V]) Cache[K, V] {
+	if config.Capacity < 0 {
+		panic(fmt.Sprintf("kol: negative capacity of Cache: %d", config.Capacity))
+	}
+	if config.Clock == nil {
+		config.Clock = SystemClock
+	}
+	return &cache[K, V]{
+		config:  config,
+		entries: make(map[K]*cacheEntry[K, V]),
+		victims: NewPriorityQueueFunc(victimOrder[K, V](config.Policy)).(*priorityQueue[*cacheEntry[K, V]]), //nolint:forcetypeassert
+	}
+}
+
+// NewLRUCache returns an empty Cache that holds at most capacity entries and evicts the least recently used one.
+func NewLRUCache[K comparable, V any](capacity int) Cache[K, V] {
+	return NewCache(CacheConfig[K, V]{Capacity: capacity, Policy: EvictLRU})
+}
+
+// NewLFUCache returns an empty Cache that holds at most capacity entries and evicts the least frequently used one.
+func NewLFUCache[K comparable, V any](capacity int) Cache[K, V] {
+	return NewCache(CacheConfig[K, V]{Capacity: capacity, Policy: EvictLFU})
+}
+
+// NewFIFOCache returns an empty Cache that holds at most capacity entries and evicts the oldest inserted one.
+func NewFIFOCache[K comparable, V any](capacity int) Cache[K, V] {
+	return NewCache(CacheConfig[K, V]{Capacity: capacity, Policy: EvictFIFO})
+}
+
+// NewTTLCache returns an empty unbounded Cache whose entries expire after the given time-to-live measured by the given clock.
+func NewTTLCache[K comparable, V any](ttl time.Duration, clock Clock) Cache[K, V] {
+	return NewCache(CacheConfig[K, V]{TTL: ttl, Clock: clock})
+}
+
+func victimOrder[K comparable, V any](policy EvictionPolicy) func(a, b *cacheEntry[K, V]) int {
+	switch policy {
+	case EvictLFU:
+		return func(a, b *cacheEntry[K, V]) int {
+			return cmp.Or(cmp.Compare(a.frequency, b.frequency), cmp.Compare(a.accessed, b.accessed))
+		}
+	case EvictFIFO:
+		return func(a, b *cacheEntry[K, V]) int {
+			return cmp.Compare(a.inserted, b.inserted)
+		}
+	default:
+		return func(a, b *cacheEntry[K, V]) int {
+			return cmp.Compare(a.accessed, b.accessed)
+		}
+	}
+}
+
+func (c *cache[K, V]) expired(e *cacheEntry[K, V], now time.Time) bool {
+	return c.config.TTL > 0 && !now.Before(e.expiresAt)
+}
+
+// live returns the entry of the given key if it is cached and not expired, without evicting it.
+func (c *cache[K, V]) live(k K) (*cacheEntry[K, V], bool) {
+	e, ok := c.entries[k]
+	if !ok || c.expired(e, c.config.Clock.Now()) {
+		return nil, false
+	}
+	return e, true
+}
+
+// lookup returns the entry of the given key, evicting it if it has expired.
+func (c *cache[K, V]) lookup(k K) (*cacheEntry[K, V], bool) {
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	if c.expired(e, c.config.Clock.Now()) {
+		c.evict(e, EvictedByExpiry)
+		return nil, false
+	}
+	return e, true
+}
+
+// liveValues returns the values of the entries that are not expired, without evicting expired ones.
+func (c *cache[K, V]) liveValues() map[K]V {
+	now := c.config.Clock.Now()
+	values := make(map[K]V, len(c.entries))
+	for k, e := range c.entries {
+		if !c.expired(e, now) {
+			values[k] = e.value
+		}
+	}
+	return values
+}
+
+func (c *cache[K, V]) touch(e *cacheEntry[K, V]) {
+	c.tick++
+	e.accessed = c.tick
+	e.frequency++
+	c.victims.Fix(e.handle)
+}
+
+func (c *cache[K, V]) delete(e *cacheEntry[K, V]) {
+	delete(c.entries, e.key)
+	c.victims.remove(e.handle)
+}
+
+func (c *cache[K, V]) evict(e *cacheEntry[K, V], reason EvictionReason) {
+	c.delete(e)
+	c.stats.Evictions++
+	if c.config.OnEvict != nil {
+		c.config.OnEvict(e.key, e.value, reason)
+	}
+}
+
+func (c *cache[K, V]) Clear() {
+	clear(c.entries)
+	c.victims.Clear()
+}
+
+func (c *cache[K, V]) Contains(k K) bool {
+	_, ok := c.live(k)
+	return ok
+}
+
+func (c *cache[K, V]) EvictExpired() int {
+	if c.config.TTL <= 0 {
+		return 0
+	}
+	now := c.config.Clock.Now()
+	n := 0
+	for _, e := range c.entries {
+		if c.expired(e, now) {
+			c.evict(e, EvictedByExpiry)
+			n++
+		}
+	}
+	return n
+}
+
+func (c *cache[K, V]) Get(k K) (V, bool) {
+	e, ok := c.lookup(k)
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(e)
+	return e.value, true
+}
+
+func (c *cache[K, V]) GetOrLoad(k K, load func(k K) (V, error)) (V, error) {
+	if v, ok := c.Get(k); ok {
+		return v, nil
+	}
+	v, err := load(k)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	c.Put(k, v)
+	return v, nil
+}
+
+func (c *cache[K, V]) Keys() Set[K] {
+	c.EvictExpired()
+	keys := make(map[K]struct{}, len(c.entries))
+	for k := range c.entries {
+		keys[k] = struct{}{}
+	}
+	return newSet(keys)
+}
+
+func (c *cache[K, V]) Peek(k K) (V, bool) {
+	e, ok := c.live(k)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *cache[K, V]) Put(k K, v V) {
+	now := c.config.Clock.Now()
+	if e, ok := c.lookup(k); ok {
+		e.value = v
+		e.expiresAt = now.Add(c.config.TTL)
+		c.touch(e)
+		return
+	}
+	if c.config.Capacity > 0 && len(c.entries) >= c.config.Capacity {
+		c.EvictExpired()
+		for len(c.entries) >= c.config.Capacity {
+			victim, _ := c.victims.Peek()
+			c.evict(victim, EvictedByCapacity)
+		}
+	}
+	c.tick++
+	e := &cacheEntry[K, V]{
+		key:       k,
+		value:     v,
+		expiresAt: now.Add(c.config.TTL),
+		inserted:  c.tick,
+		accessed:  c.tick,
+		frequency: 1,
+	}
+	e.handle = c.victims.Push(e)
+	c.entries[k] = e
+}
+
+func (c *cache[K, V]) Remove(k K) bool {
+	e, ok := c.entries[k]
+	if ok {
+		c.delete(e)
+	}
+	return ok
+}
+
+func (c *cache[K, V]) Size() int {
+	c.EvictExpired()
+	return len(c.entries)
+}
+
+func (c *cache[K, V]) Stats() CacheStats {
+	return c.stats
+}
+
+var (
+	_ fmt.Formatter  = (*cache[int, string])(nil)
+	_ fmt.Stringer   = (*cache[int, string])(nil)
+	_ slog.LogValuer = (*cache[int, string])(nil)
+)
+
+func (c *cache[K, V]) Format(f fmt.State, verb rune) {
+	values := c.liveValues()
+	formatMap(f, verb, mapTypeName[K, V]("Cache"), len(values), values)
+}
+
+func (c *cache[K, V]) String() string {
+	return fmt.Sprint(c)
+}
+
+func (c *cache[K, V]) LogValue() slog.Value {
+	values := c.liveValues()
+	return logMap(mapTypeName[K, V]("Cache"), len(values), values)
+}
+
+// SynchronizedCache returns a Cache backed by the given cache and guarded by a sync.Mutex.
+// The given cache must not be accessed directly afterwards.
+//
+// GetOrLoad holds the lock while loading a value, so a key is never loaded twice concurrently.
+// Eviction listeners are called with the lock held, so they must not access the cache.
+func SynchronizedCache[K comparable, V any](c Cache[K, V]) Cache[K, V] {
+	return &synchronizedCache[K, V]{inner: c}
+}
+
+type synchronizedCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	inner Cache[K, V]
+}
+
+var _ Cache[int, string] = (*synchronizedCache[int, string])(nil)
+
+func (c *synchronizedCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Clear()
+}
+
+func (c *synchronizedCache[K, V]) Contains(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Contains(k)
+}
+
+func (c *synchronizedCache[K, V]) EvictExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.EvictExpired()
+}
+
+func (c *synchronizedCache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Get(k)
+}
+
+func (c *synchronizedCache[K, V]) GetOrLoad(k K, load func(k K) (V, error)) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.GetOrLoad(k, load)
+}
+
+func (c *synchronizedCache[K, V]) Keys() Set[K] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Keys()
+}
+
+func (c *synchronizedCache[K, V]) Peek(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Peek(k)
+}
+
+func (c *synchronizedCache[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Put(k, v)
+}
+
+func (c *synchronizedCache[K, V]) Remove(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Remove(k)
+}
+
+func (c *synchronizedCache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Size()
+}
+
+func (c *synchronizedCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Stats()
+}
+
+func (c *synchronizedCache[K, V]) Format(f fmt.State, verb rune) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Format(f, verb)
+}
+
+func (c *synchronizedCache[K, V]) String() string {
+	return fmt.Sprint(c)
+}
+
+func (c *synchronizedCache[K, V]) LogValue() slog.Value {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.LogValue()
+}
@@ -0,0 +1,258 @@
+package kol
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type eviction struct {
+	key    string
+	reason EvictionReason
+}
+
+func TestCache_EvictionPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   EvictionPolicy
+		wantKeys []string
+		want     []eviction
+	}{
+		{
+			name:     "LRU",
+			policy:   EvictLRU,
+			wantKeys: []string{"a", "c", "d"},
+			want:     []eviction{{key: "b", reason: EvictedByCapacity}},
+		},
+		{
+			name:     "LFU",
+			policy:   EvictLFU,
+			wantKeys: []string{"a", "b", "d"},
+			want:     []eviction{{key: "c", reason: EvictedByCapacity}},
+		},
+		{
+			name:     "FIFO",
+			policy:   EvictFIFO,
+			wantKeys: []string{"b", "c", "d"},
+			want:     []eviction{{key: "a", reason: EvictedByCapacity}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []eviction
+			c := NewCache(CacheConfig[string, int]{
+				Capacity: 3,
+				Policy:   tt.policy,
+				OnEvict: func(key string, _ int, reason EvictionReason) {
+					evicted = append(evicted, eviction{key: key, reason: reason})
+				},
+			})
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("c", 3)
+			c.Get("b")
+			c.Get("b")
+			c.Get("c")
+			c.Get("a")
+			c.Put("d", 4)
+
+			assert.ElementsMatch(t, tt.wantKeys, c.Keys().ToSlice())
+			assert.Equal(t, tt.want, evicted)
+			assert.Equal(t, 3, c.Size())
+		})
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	clock := newFakeClock()
+	var evicted []eviction
+	c := NewCache(CacheConfig[string, int]{
+		TTL:   time.Minute,
+		Clock: clock,
+		OnEvict: func(key string, _ int, reason EvictionReason) {
+			evicted = append(evicted, eviction{key: key, reason: reason})
+		},
+	})
+	c.Put("a", 1)
+	clock.Advance(30 * time.Second)
+	c.Put("b", 2)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	clock.Advance(30 * time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.True(t, c.Contains("b"))
+	assert.Equal(t, []eviction{{key: "a", reason: EvictedByExpiry}}, evicted)
+
+	c.Put("b", 3)
+	clock.Advance(59 * time.Second)
+	assert.Equal(t, 0, c.EvictExpired())
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, c.EvictExpired())
+	assert.Equal(t, 0, c.Size())
+}
+
+func TestCache_PutFullPrefersExpired(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCache(CacheConfig[string, int]{Capacity: 2, TTL: time.Minute, Clock: clock})
+	c.Put("a", 1)
+	clock.Advance(30 * time.Second)
+	c.Put("b", 2)
+	c.Get("a")
+	clock.Advance(30 * time.Second)
+	c.Put("c", 3)
+	assert.ElementsMatch(t, []string{"b", "c"}, c.Keys().ToSlice())
+}
+
+func TestCache_GetOrLoad(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	loads := 0
+	load := func(k string) (int, error) {
+		loads++
+		return len(k), nil
+	}
+
+	v, err := c.GetOrLoad("abc", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	v, err = c.GetOrLoad("abc", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 1, loads)
+
+	errLoad := errors.New("load failed")
+	_, err = c.GetOrLoad("x", func(string) (int, error) {
+		return 0, errLoad
+	})
+	assert.ErrorIs(t, err, errLoad)
+	assert.False(t, c.Contains("x"))
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2}, c.Stats())
+	assert.InDelta(t, 1.0/3, c.Stats().HitRate(), 1e-9)
+}
+
+func TestCache_PeekAndRemove(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	v, ok := c.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	c.Put("c", 3)
+	assert.ElementsMatch(t, []string{"b", "c"}, c.Keys().ToSlice())
+	assert.Equal(t, CacheStats{Evictions: 1}, c.Stats())
+
+	assert.True(t, c.Remove("b"))
+	assert.False(t, c.Remove("b"))
+	c.Clear()
+	assert.Equal(t, 0, c.Size())
+	c.Put("d", 4)
+	assert.ElementsMatch(t, []string{"d"}, c.Keys().ToSlice())
+}
+
+func TestCache_PeekExpired(t *testing.T) {
+	clock := newFakeClock()
+	var evicted []eviction
+	c := NewCache(CacheConfig[string, int]{
+		TTL:   time.Minute,
+		Clock: clock,
+		OnEvict: func(key string, _ int, reason EvictionReason) {
+			evicted = append(evicted, eviction{key: key, reason: reason})
+		},
+	})
+	c.Put("a", 1)
+	clock.Advance(time.Minute)
+
+	_, ok := c.Peek("a")
+	assert.False(t, ok)
+	assert.False(t, c.Contains("a"))
+	assert.Equal(t, CacheStats{}, c.Stats())
+	assert.Empty(t, evicted)
+
+	assert.Equal(t, 1, c.EvictExpired())
+	assert.Equal(t, []eviction{{key: "a", reason: EvictedByExpiry}}, evicted)
+}
+
+func TestCache_Put(t *testing.T) {
+	c := NewFIFOCache[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 10)
+	c.Put("c", 3)
+	assert.ElementsMatch(t, []string{"b", "c"}, c.Keys().ToSlice())
+
+	l := NewLFUCache[string, int](2)
+	l.Put("a", 1)
+	l.Put("a", 10)
+	l.Put("b", 2)
+	l.Put("c", 3)
+	v, _ := l.Get("a")
+	assert.Equal(t, 10, v)
+	assert.ElementsMatch(t, []string{"a", "c"}, l.Keys().ToSlice())
+}
+
+func TestNewCache_Panic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewLRUCache[string, int](-1)
+	})
+}
+
+func TestNewTTLCache(t *testing.T) {
+	clock := newFakeClock()
+	c := NewTTLCache[string, int](time.Second, clock)
+	for i := range 100 {
+		c.Put(string(rune('a'+i%26))+string(rune('a'+i/26)), i)
+	}
+	assert.Equal(t, 100, c.Size())
+	clock.Advance(time.Second)
+	assert.Equal(t, 0, c.Size())
+}
+
+func TestSynchronizedCache(t *testing.T) {
+	c := SynchronizedCache(NewLRUCache[int, int](50))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				_, _ = c.GetOrLoad(i*100+j, func(k int) (int, error) {
+					return k * 2, nil
+				})
+				c.Get(j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, c.Size())
+	assert.Equal(t, 50, c.Keys().Size())
+	stats := c.Stats()
+	assert.Equal(t, 1600, stats.Hits+stats.Misses)
+}
@@ -0,0 +1,18 @@
+package kol
+
+import "time"
+
+// Clock provides the current time to collections that expire their elements.
+// Replace it with a fake implementation to test expiration without waiting for real time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the current system time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
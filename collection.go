@@ -1,5 +1,10 @@
 package kol
 
+import (
+	"fmt"
+	"log/slog"
+)
+
 // Collection is a generic collection of elements.
 type Collection[E comparable] interface {
 	Iterable[E]
@@ -8,12 +13,25 @@ type Collection[E comparable] interface {
 	Add(elements ...E)
 	// Clear removes all elements.
 	Clear()
+	// Format implements fmt.Formatter. A collection is formatted like a slice as `[1, 2, 3]`,
+	// and un-ordered collections such as sets are formatted in a deterministic sorted order.
+	// %+v prefixes the elements with the type and the size,
+	// and %.Nv formats only the first N elements followed by "...and M more".
+	Format(f fmt.State, verb rune)
 	// IsEmpty returns `true` if the collection is empty, `false` otherwise.
 	IsEmpty() bool
+	// LogValue implements slog.LogValuer, and returns a group of the type, the size
+	// and a sample of the elements limited by SetLogSampleSize, in the same order as Format.
+	LogValue() slog.Value
+	// MutableIterator returns an iterator over the elements of this collection
+	// that supports removing elements during iteration.
+	MutableIterator() MutableIterator[E]
 	// Remove removes specified elements.
 	Remove(elements ...E)
 	// Retain retains only elements in this collection that are contained in specified elements.
 	Retain(elements ...E)
 	// Size is size of this collection.
 	Size() int
+	// String returns the elements formatted by %v.
+	String() string
 }
@@ -0,0 +1,503 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/slices"
+)
+
+// ConcurrentList is a List safe for concurrent use by multiple goroutines.
+type ConcurrentList[E comparable] interface {
+	List[E]
+
+	// AddIfAbsent atomically adds the given element if it is not contained in this list.
+	// It returns `true` if the element has been added.
+	AddIfAbsent(element E) bool
+	// ComputeIfAbsent atomically returns the first element matching the given predicate,
+	// or adds and returns the result of the compute function if there is no such element.
+	// The given functions must not access this list.
+	ComputeIfAbsent(predicate func(element E) bool, compute func() E) E
+	// Snapshot returns a copy of this list that can be iterated without synchronization.
+	Snapshot() List[E]
+}
+
+// concurrentList is a copy-on-write ConcurrentList.
+// Every modification copies the elements, so reads never block and never observe a partial modification.
+// It suits read-heavy use such as caches, where modifications are rare.
+type concurrentList[E comparable] struct {
+	mu       sync.Mutex // serializes modifications
+	elements atomic.Pointer[[]E]
+}
+
+var _ ConcurrentList[int] = (*concurrentList[int])(nil)
+
+// NewConcurrentList returns a copy-on-write ConcurrentList containing the given elements.
+func NewConcurrentList[E comparable](elements ...E) ConcurrentList[E] {
+	l := &concurrentList[E]{}
+	cloned := append(make([]E, 0, len(elements)), elements...)
+	l.elements.Store(&cloned)
+	return l
+}
+
+// view returns a list sharing the current elements, which must not be modified.
+func (l *concurrentList[E]) view() *list[E] {
+	return &list[E]{elements: *l.elements.Load()}
+}
+
+// modify applies the given modification to a copy of the current elements,
+// and publishes the copy if it has been modified.
+func (l *concurrentList[E]) modify(f func(cloned *list[E])) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cloned := &list[E]{elements: slices.Clone(*l.elements.Load()), mod: &modCount{}}
+	f(cloned)
+	if cloned.mod.count() > 0 {
+		l.elements.Store(&cloned.elements)
+	}
+}
+
+func (l *concurrentList[E]) Snapshot() List[E] {
+	return l.view().clone()
+}
+
+func (l *concurrentList[E]) Add(elements ...E) {
+	l.modify(func(cloned *list[E]) {
+		cloned.Add(elements...)
+	})
+}
+
+func (l *concurrentList[E]) AddIfAbsent(e E) bool {
+	added := false
+	l.modify(func(cloned *list[E]) {
+		if !cloned.Contains(e) {
+			cloned.Add(e)
+			added = true
+		}
+	})
+	return added
+}
+
+func (l *concurrentList[E]) Clear() {
+	l.modify(func(cloned *list[E]) {
+		cloned.Clear()
+	})
+}
+
+func (l *concurrentList[E]) ComputeIfAbsent(p func(e E) bool, compute func() E) E {
+	var res E
+	l.modify(func(cloned *list[E]) {
+		if e, ok := cloned.Find(p); ok {
+			res = e
+			return
+		}
+		res = compute()
+		cloned.Add(res)
+	})
+	return res
+}
+
+func (l *concurrentList[E]) IsEmpty() bool {
+	return l.Size() == 0
+}
+
+func (l *concurrentList[E]) MutableIterator() MutableIterator[E] {
+	return l.ListIterator()
+}
+
+func (l *concurrentList[E]) Remove(targets ...E) {
+	l.modify(func(cloned *list[E]) {
+		cloned.Remove(targets...)
+	})
+}
+
+func (l *concurrentList[E]) Retain(targets ...E) {
+	l.modify(func(cloned *list[E]) {
+		cloned.Retain(targets...)
+	})
+}
+
+func (l *concurrentList[E]) Size() int {
+	return len(*l.elements.Load())
+}
+
+func (l *concurrentList[E]) All(p func(e E) bool) bool {
+	return l.view().All(p)
+}
+
+func (l *concurrentList[E]) Any(p func(e E) bool) bool {
+	return l.view().Any(p)
+}
+
+func (l *concurrentList[E]) AsSequence() Sequence[E] {
+	return NewSequence(*l.elements.Load()...)
+}
+
+func (l *concurrentList[E]) Contains(e E) bool {
+	return l.view().Contains(e)
+}
+
+func (l *concurrentList[E]) ContainsAll(elements ...E) bool {
+	return l.view().ContainsAll(elements...)
+}
+
+func (l *concurrentList[E]) ContainsAny(elements ...E) bool {
+	return l.view().ContainsAny(elements...)
+}
+
+func (l *concurrentList[E]) Count(p func(e E) bool) int {
+	return l.view().Count(p)
+}
+
+func (l *concurrentList[E]) Distinct() Collection[E] {
+	return l.view().Distinct()
+}
+
+func (l *concurrentList[E]) Drop(n uint) Collection[E] {
+	return l.Snapshot().Drop(n)
+}
+
+func (l *concurrentList[E]) DropWhile(p func(e E) bool) Collection[E] {
+	return l.Snapshot().DropWhile(p)
+}
+
+func (l *concurrentList[E]) ElementAt(idx int) (E, bool) {
+	return l.view().ElementAt(idx)
+}
+
+func (l *concurrentList[E]) ElementAtOrElse(idx int, f func() E) E {
+	return l.view().ElementAtOrElse(idx, f)
+}
+
+func (l *concurrentList[E]) Equals(other Iterable[E]) bool {
+	return l.view().Equals(other)
+}
+
+func (l *concurrentList[E]) Filter(p func(e E) bool) Collection[E] {
+	return l.view().Filter(p)
+}
+
+func (l *concurrentList[E]) FilterIndexed(p func(idx int, e E) bool) Collection[E] {
+	return l.view().FilterIndexed(p)
+}
+
+func (l *concurrentList[E]) Find(p func(e E) bool) (E, bool) {
+	return l.view().Find(p)
+}
+
+func (l *concurrentList[E]) FindLast(p func(e E) bool) (E, bool) {
+	return l.view().FindLast(p)
+}
+
+func (l *concurrentList[E]) ForEach(a func(e E)) {
+	l.view().ForEach(a)
+}
+
+func (l *concurrentList[E]) ForEachIndexed(a func(idx int, e E)) {
+	l.view().ForEachIndexed(a)
+}
+
+func (l *concurrentList[E]) Hash() uint64 {
+	return l.view().Hash()
+}
+
+func (l *concurrentList[E]) IndexOf(e E) int {
+	return l.view().IndexOf(e)
+}
+
+func (l *concurrentList[E]) IndexOfFirst(p func(e E) bool) int {
+	return l.view().IndexOfFirst(p)
+}
+
+func (l *concurrentList[E]) IndexOfLast(p func(e E) bool) int {
+	return l.view().IndexOfLast(p)
+}
+
+func (l *concurrentList[E]) Intersect(other Iterable[E]) Set[E] {
+	return l.view().Intersect(other)
+}
+
+func (l *concurrentList[E]) IsDisjointWith(other Iterable[E]) bool {
+	return l.view().IsDisjointWith(other)
+}
+
+func (l *concurrentList[E]) IsSubsetOf(other Iterable[E]) bool {
+	return l.view().IsSubsetOf(other)
+}
+
+func (l *concurrentList[E]) IsSupersetOf(other Iterable[E]) bool {
+	return l.view().IsSupersetOf(other)
+}
+
+func (l *concurrentList[E]) Iterator() Iterator[E] {
+	return l.ListIterator()
+}
+
+func (l *concurrentList[E]) IntersectOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.view().IntersectOrdered(other, semantics)
+}
+
+func (l *concurrentList[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return l.view().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (l *concurrentList[E]) ListIterator() ListIterator[E] {
+	return newConcurrentListIterator(l)
+}
+
+func (l *concurrentList[E]) Map(t func(e E) E) Collection[E] {
+	return l.view().Map(t)
+}
+
+func (l *concurrentList[E]) MapIndexed(t func(idx int, e E) E) Collection[E] {
+	return l.view().MapIndexed(t)
+}
+
+func (l *concurrentList[E]) Minus(e ...E) Collection[E] {
+	return l.view().Minus(e...)
+}
+
+func (l *concurrentList[E]) None(p func(e E) bool) bool {
+	return l.view().None(p)
+}
+
+func (l *concurrentList[E]) Partition(p func(e E) bool) (List[E], List[E]) {
+	return l.view().Partition(p)
+}
+
+func (l *concurrentList[E]) Plus(e ...E) Collection[E] {
+	return l.view().Plus(e...)
+}
+
+func (l *concurrentList[E]) Reversed() List[E] {
+	return l.view().Reversed()
+}
+
+func (l *concurrentList[E]) Shuffled() List[E] {
+	return l.view().Shuffled()
+}
+
+func (l *concurrentList[E]) Single(p func(e E) bool) (E, bool) {
+	return l.view().Single(p)
+}
+
+func (l *concurrentList[E]) Subtract(other Iterable[E]) Set[E] {
+	return l.view().Subtract(other)
+}
+
+func (l *concurrentList[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return l.view().SymmetricDifference(other)
+}
+
+func (l *concurrentList[E]) SubtractOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.view().SubtractOrdered(other, semantics)
+}
+
+func (l *concurrentList[E]) Take(n uint) Collection[E] {
+	return l.Snapshot().Take(n)
+}
+
+func (l *concurrentList[E]) TakeWhile(p func(e E) bool) Collection[E] {
+	return l.Snapshot().TakeWhile(p)
+}
+
+func (l *concurrentList[E]) UnionOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.view().UnionOrdered(other, semantics)
+}
+
+func (l *concurrentList[E]) ToList() List[E] {
+	return l.Snapshot()
+}
+
+func (l *concurrentList[E]) ToSet() Set[E] {
+	return l.view().ToSet()
+}
+
+func (l *concurrentList[E]) ToSlice() []E {
+	return l.view().ToSlice()
+}
+
+func (l *concurrentList[E]) Union(other Iterable[E]) Set[E] {
+	return l.view().Union(other)
+}
+
+// concurrentListIterator iterates over a snapshot of a concurrentList taken when the iterator is created,
+// so it never observes concurrent modifications.
+// Modifications through the iterator are applied to the snapshot and published to the list,
+// unless the list has been modified by others since the snapshot was published,
+// in which case they fail with ErrConcurrentModification.
+type concurrentListIterator[E comparable] struct {
+	list     *concurrentList[E]
+	snapshot *listIterator[E]
+	cloned   *list[E]
+	expected *[]E
+	err      error
+}
+
+var _ ListIterator[int] = (*concurrentListIterator[int])(nil)
+
+func newConcurrentListIterator[E comparable](l *concurrentList[E]) *concurrentListIterator[E] {
+	expected := l.elements.Load()
+	cloned := &list[E]{elements: slices.Clone(*expected)}
+	return &concurrentListIterator[E]{
+		list:     l,
+		snapshot: newListIterator[E](cloned),
+		cloned:   cloned,
+		expected: expected,
+	}
+}
+
+// modify applies the given modification to the snapshot and publishes it to the list.
+func (i *concurrentListIterator[E]) modify(f func() bool) bool {
+	if i.err != nil {
+		return false
+	}
+	i.list.mu.Lock()
+	defer i.list.mu.Unlock()
+	if i.list.elements.Load() != i.expected {
+		i.err = concurrentModification()
+		return false
+	}
+	if !f() {
+		return false
+	}
+	published := slices.Clone(i.cloned.elements)
+	i.list.elements.Store(&published)
+	i.expected = &published
+	return true
+}
+
+func (i *concurrentListIterator[E]) HasNext() bool {
+	return i.snapshot.HasNext()
+}
+
+func (i *concurrentListIterator[E]) Next() (E, bool) {
+	if i.err != nil {
+		var zero E
+		return zero, false
+	}
+	return i.snapshot.Next()
+}
+
+func (i *concurrentListIterator[E]) Err() error {
+	return i.err
+}
+
+func (i *concurrentListIterator[E]) Remove() bool {
+	return i.modify(i.snapshot.Remove)
+}
+
+func (i *concurrentListIterator[E]) HasPrevious() bool {
+	return i.snapshot.HasPrevious()
+}
+
+func (i *concurrentListIterator[E]) Previous() (E, bool) {
+	if i.err != nil {
+		var zero E
+		return zero, false
+	}
+	return i.snapshot.Previous()
+}
+
+func (i *concurrentListIterator[E]) NextIndex() int {
+	return i.snapshot.NextIndex()
+}
+
+func (i *concurrentListIterator[E]) PreviousIndex() int {
+	return i.snapshot.PreviousIndex()
+}
+
+func (i *concurrentListIterator[E]) Set(e E) bool {
+	return i.modify(func() bool {
+		return i.snapshot.Set(e)
+	})
+}
+
+func (i *concurrentListIterator[E]) Add(e E) {
+	i.modify(func() bool {
+		i.snapshot.Add(e)
+		return true
+	})
+}
+
+// replaceAll replaces the elements of this list with the given elements.
+func (l *concurrentList[E]) replaceAll(elements []E) error {
+	l.modify(func(cloned *list[E]) {
+		cloned.Clear()
+		cloned.Add(elements...)
+	})
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*concurrentList[int])(nil)
+	_ json.Unmarshaler = (*concurrentList[int])(nil)
+)
+
+// MarshalJSON encodes a snapshot of this list as a JSON array.
+func (l *concurrentList[E]) MarshalJSON() ([]byte, error) {
+	return l.view().MarshalJSON()
+}
+
+// UnmarshalJSON atomically replaces the elements of this list with the decoded JSON array.
+func (l *concurrentList[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return l.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*concurrentList[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*concurrentList[int])(nil)
+	_ gob.GobEncoder             = (*concurrentList[int])(nil)
+	_ gob.GobDecoder             = (*concurrentList[int])(nil)
+)
+
+// MarshalBinary encodes a snapshot of this list into a compact binary form.
+func (l *concurrentList[E]) MarshalBinary() ([]byte, error) {
+	return l.view().MarshalBinary()
+}
+
+// UnmarshalBinary atomically replaces the elements of this list with the decoded binary form.
+func (l *concurrentList[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return l.replaceAll(elements)
+}
+
+// GobEncode encodes this list by MarshalBinary.
+func (l *concurrentList[E]) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+// GobDecode decodes this list by UnmarshalBinary.
+func (l *concurrentList[E]) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*concurrentList[int])(nil)
+	_ fmt.Stringer   = (*concurrentList[int])(nil)
+	_ slog.LogValuer = (*concurrentList[int])(nil)
+)
+
+func (l *concurrentList[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "ConcurrentList", l.ToSlice())
+}
+
+func (l *concurrentList[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *concurrentList[E]) LogValue() slog.Value {
+	return logCollection("ConcurrentList", l.ToSlice())
+}
@@ -0,0 +1,86 @@
+package kol
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentList(t *testing.T) {
+	t.Run("concurrent modifications", func(t *testing.T) {
+		l := NewConcurrentList[int]()
+		var wg sync.WaitGroup
+		for i := range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Add(i)
+				l.AddIfAbsent(i)
+				l.ForEach(func(_ int) {})
+				l.ElementAt(0)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 100, l.Size())
+		assert.Equal(t, 100, l.Distinct().Size())
+	})
+
+	t.Run("readers never observe modifications", func(t *testing.T) {
+		l := NewConcurrentList(1, 2, 3)
+		var got []int
+		l.ForEach(func(e int) {
+			l.Add(e * 10)
+			got = append(got, e)
+		})
+		assert.Equal(t, []int{1, 2, 3}, got)
+		assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, l.ToSlice())
+	})
+
+	t.Run("derived lists do not share elements", func(t *testing.T) {
+		l := NewConcurrentList(1, 2, 3)
+		taken := l.Take(2)
+		taken.Add(99)
+		assert.Equal(t, []int{1, 2, 3}, l.ToSlice())
+		assert.Equal(t, NewList(2, 3), l.Drop(1))
+	})
+
+	t.Run("list iterator publishes modifications", func(t *testing.T) {
+		l := NewConcurrentList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		assert.True(t, iter.Set(10))
+		iter.Next()
+		assert.True(t, iter.Remove())
+		iter.Add(20)
+		assert.Equal(t, []int{10, 20, 3}, l.ToSlice())
+		assert.NoError(t, iter.Err())
+	})
+
+	t.Run("list iterator fails on modifications by others", func(t *testing.T) {
+		l := NewConcurrentList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		l.Add(4)
+		assert.False(t, iter.Remove())
+		assert.ErrorIs(t, iter.Err(), ErrConcurrentModification)
+		assert.Equal(t, []int{1, 2, 3, 4}, l.ToSlice())
+	})
+
+	t.Run("no-op modifications do not fail iterators", func(t *testing.T) {
+		l := NewConcurrentList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		l.AddIfAbsent(1)
+		l.Remove(4)
+		assert.True(t, iter.Remove())
+		assert.Equal(t, []int{2, 3}, l.ToSlice())
+	})
+
+	t.Run("compute if absent", func(t *testing.T) {
+		l := NewConcurrentList(1, 2)
+		assert.Equal(t, 2, l.ComputeIfAbsent(func(e int) bool { return e%2 == 0 }, func() int { return 4 }))
+		assert.Equal(t, 5, l.ComputeIfAbsent(func(e int) bool { return e > 2 }, func() int { return 5 }))
+		assert.Equal(t, NewList(1, 2, 5), l.Snapshot())
+	})
+}
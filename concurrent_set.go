@@ -0,0 +1,376 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"log/slog"
+	"sync"
+)
+
+// ConcurrentSet is a Set safe for concurrent use by multiple goroutines.
+type ConcurrentSet[E comparable] interface {
+	Set[E]
+
+	// AddIfAbsent atomically adds the given element if it is not contained in this set.
+	// It returns `true` if the element has been added.
+	AddIfAbsent(element E) bool
+	// ComputeIfAbsent atomically returns an element matching the given predicate,
+	// or adds and returns the result of the compute function if there is no such element.
+	// The given functions must not access this set.
+	ComputeIfAbsent(predicate func(element E) bool, compute func() E) E
+	// Snapshot returns a copy of this set that can be iterated without synchronization.
+	Snapshot() Set[E]
+}
+
+const concurrentSetShardCount = 32
+
+// concurrentSet is a ConcurrentSet that distributes elements over shards guarded by their own locks,
+// so that operations on different elements rarely contend.
+// Operations spanning all shards, e.g. Size and Snapshot, lock the shards one at a time,
+// so they are not atomic with respect to concurrent modifications.
+type concurrentSet[E comparable] struct {
+	seed   maphash.Seed
+	shards [concurrentSetShardCount]concurrentSetShard[E]
+}
+
+type concurrentSetShard[E comparable] struct {
+	mu sync.RWMutex
+	m  map[E]struct{}
+}
+
+var _ ConcurrentSet[int] = (*concurrentSet[int])(nil)
+
+// NewConcurrentSet returns a sharded ConcurrentSet containing the given elements.
+func NewConcurrentSet[E comparable](elements ...E) ConcurrentSet[E] {
+	s := &concurrentSet[E]{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].m = make(map[E]struct{})
+	}
+	s.Add(elements...)
+	return s
+}
+
+func (s *concurrentSet[E]) shard(e E) *concurrentSetShard[E] {
+	return &s.shards[maphash.Comparable(s.seed, e)%concurrentSetShardCount]
+}
+
+func (s *concurrentSet[E]) lockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.Lock()
+	}
+}
+
+func (s *concurrentSet[E]) unlockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.Unlock()
+	}
+}
+
+func (s *concurrentSet[E]) Snapshot() Set[E] {
+	m := make(map[E]struct{})
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		for e := range sh.m {
+			m[e] = struct{}{}
+		}
+		sh.mu.RUnlock()
+	}
+	return newSet(m)
+}
+
+func (s *concurrentSet[E]) Add(elements ...E) {
+	for _, e := range elements {
+		sh := s.shard(e)
+		sh.mu.Lock()
+		sh.m[e] = struct{}{}
+		sh.mu.Unlock()
+	}
+}
+
+func (s *concurrentSet[E]) AddIfAbsent(e E) bool {
+	sh := s.shard(e)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if _, ok := sh.m[e]; ok {
+		return false
+	}
+	sh.m[e] = struct{}{}
+	return true
+}
+
+func (s *concurrentSet[E]) Clear() {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		clear(sh.m)
+		sh.mu.Unlock()
+	}
+}
+
+func (s *concurrentSet[E]) ComputeIfAbsent(p func(e E) bool, compute func() E) E {
+	s.lockAll()
+	defer s.unlockAll()
+	for i := range s.shards {
+		for e := range s.shards[i].m {
+			if p(e) {
+				return e
+			}
+		}
+	}
+	e := compute()
+	s.shard(e).m[e] = struct{}{}
+	return e
+}
+
+func (s *concurrentSet[E]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *concurrentSet[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(s.ToSlice(), func(e E) {
+		s.Remove(e)
+	}, nil)
+}
+
+func (s *concurrentSet[E]) Remove(targets ...E) {
+	for _, t := range targets {
+		sh := s.shard(t)
+		sh.mu.Lock()
+		delete(sh.m, t)
+		sh.mu.Unlock()
+	}
+}
+
+func (s *concurrentSet[E]) Retain(targets ...E) {
+	retained := NewSet(targets...)
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		for e := range sh.m {
+			if !retained.Contains(e) {
+				delete(sh.m, e)
+			}
+		}
+		sh.mu.Unlock()
+	}
+}
+
+func (s *concurrentSet[E]) Size() int {
+	size := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		size += len(sh.m)
+		sh.mu.RUnlock()
+	}
+	return size
+}
+
+func (s *concurrentSet[E]) All(p func(e E) bool) bool {
+	return s.Snapshot().All(p)
+}
+
+func (s *concurrentSet[E]) Any(p func(e E) bool) bool {
+	return s.Snapshot().Any(p)
+}
+
+func (s *concurrentSet[E]) AsSequence() Sequence[E] {
+	return s.Snapshot().AsSequence()
+}
+
+func (s *concurrentSet[E]) Contains(e E) bool {
+	sh := s.shard(e)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	_, ok := sh.m[e]
+	return ok
+}
+
+func (s *concurrentSet[E]) ContainsAll(elements ...E) bool {
+	return s.Snapshot().ContainsAll(elements...)
+}
+
+func (s *concurrentSet[E]) ContainsAny(elements ...E) bool {
+	return s.Snapshot().ContainsAny(elements...)
+}
+
+func (s *concurrentSet[E]) Count(p func(e E) bool) int {
+	return s.Snapshot().Count(p)
+}
+
+func (s *concurrentSet[E]) Distinct() Collection[E] {
+	return s.Snapshot()
+}
+
+func (s *concurrentSet[E]) Equals(other Set[E]) bool {
+	return s.Snapshot().Equals(other)
+}
+
+func (s *concurrentSet[E]) Filter(p func(e E) bool) Collection[E] {
+	return s.Snapshot().Filter(p)
+}
+
+func (s *concurrentSet[E]) Find(p func(e E) bool) (E, bool) {
+	return s.Snapshot().Find(p)
+}
+
+func (s *concurrentSet[E]) ForEach(a func(e E)) {
+	s.Snapshot().ForEach(a)
+}
+
+func (s *concurrentSet[E]) Hash() uint64 {
+	return s.Snapshot().Hash()
+}
+
+func (s *concurrentSet[E]) Intersect(other Iterable[E]) Set[E] {
+	return s.Snapshot().Intersect(other)
+}
+
+func (s *concurrentSet[E]) IsDisjointWith(other Iterable[E]) bool {
+	return s.Snapshot().IsDisjointWith(other)
+}
+
+func (s *concurrentSet[E]) IsSubsetOf(other Iterable[E]) bool {
+	return s.Snapshot().IsSubsetOf(other)
+}
+
+func (s *concurrentSet[E]) IsSupersetOf(other Iterable[E]) bool {
+	return s.Snapshot().IsSupersetOf(other)
+}
+
+func (s *concurrentSet[E]) Iterator() Iterator[E] {
+	return s.MutableIterator()
+}
+
+func (s *concurrentSet[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return s.Snapshot().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (s *concurrentSet[E]) Map(t func(e E) E) Collection[E] {
+	return s.Snapshot().Map(t)
+}
+
+func (s *concurrentSet[E]) Minus(e ...E) Collection[E] {
+	return s.Snapshot().Minus(e...)
+}
+
+func (s *concurrentSet[E]) None(p func(e E) bool) bool {
+	return s.Snapshot().None(p)
+}
+
+func (s *concurrentSet[E]) Plus(e ...E) Collection[E] {
+	return s.Snapshot().Plus(e...)
+}
+
+func (s *concurrentSet[E]) Single(p func(e E) bool) (E, bool) {
+	return s.Snapshot().Single(p)
+}
+
+func (s *concurrentSet[E]) Subtract(other Iterable[E]) Set[E] {
+	return s.Snapshot().Subtract(other)
+}
+
+func (s *concurrentSet[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return s.Snapshot().SymmetricDifference(other)
+}
+
+func (s *concurrentSet[E]) ToList() List[E] {
+	return s.Snapshot().ToList()
+}
+
+func (s *concurrentSet[E]) ToSet() Set[E] {
+	return s.Snapshot()
+}
+
+func (s *concurrentSet[E]) ToSlice() []E {
+	return s.Snapshot().ToSlice()
+}
+
+func (s *concurrentSet[E]) Union(other Iterable[E]) Set[E] {
+	return s.Snapshot().Union(other)
+}
+
+// replaceAll replaces the elements of this set with the given elements.
+func (s *concurrentSet[E]) replaceAll(elements []E) error {
+	s.lockAll()
+	defer s.unlockAll()
+	for i := range s.shards {
+		clear(s.shards[i].m)
+	}
+	for _, e := range elements {
+		s.shard(e).m[e] = struct{}{}
+	}
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*concurrentSet[int])(nil)
+	_ json.Unmarshaler = (*concurrentSet[int])(nil)
+)
+
+// MarshalJSON encodes a snapshot of this set as a JSON array in arbitrary order.
+func (s *concurrentSet[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(s.ToSlice())
+}
+
+// UnmarshalJSON atomically replaces the elements of this set with the decoded JSON array.
+func (s *concurrentSet[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*concurrentSet[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*concurrentSet[int])(nil)
+	_ gob.GobEncoder             = (*concurrentSet[int])(nil)
+	_ gob.GobDecoder             = (*concurrentSet[int])(nil)
+)
+
+// MarshalBinary encodes a snapshot of this set into a compact binary form.
+func (s *concurrentSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(s.ToSlice())
+}
+
+// UnmarshalBinary atomically replaces the elements of this set with the decoded binary form.
+func (s *concurrentSet[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+// GobEncode encodes this set by MarshalBinary.
+func (s *concurrentSet[E]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode decodes this set by UnmarshalBinary.
+func (s *concurrentSet[E]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*concurrentSet[int])(nil)
+	_ fmt.Stringer   = (*concurrentSet[int])(nil)
+	_ slog.LogValuer = (*concurrentSet[int])(nil)
+)
+
+func (s *concurrentSet[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "ConcurrentSet", sortedForDisplay(s.ToSlice()))
+}
+
+func (s *concurrentSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *concurrentSet[E]) LogValue() slog.Value {
+	return logCollection("ConcurrentSet", sortedForDisplay(s.ToSlice()))
+}
@@ -0,0 +1,74 @@
+package kol
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentSet(t *testing.T) {
+	t.Run("concurrent modifications", func(t *testing.T) {
+		s := NewConcurrentSet[int]()
+		var wg sync.WaitGroup
+		for i := range 1000 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Add(i, i+1000)
+				s.Remove(i + 1000)
+				s.Contains(i)
+				s.Size()
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1000, s.Size())
+		assert.True(t, s.All(func(e int) bool { return e < 1000 }))
+	})
+
+	t.Run("add if absent", func(t *testing.T) {
+		s := NewConcurrentSet[int]()
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		added := 0
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if s.AddIfAbsent(1) {
+					mu.Lock()
+					added++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, added)
+	})
+
+	t.Run("compute if absent", func(t *testing.T) {
+		s := NewConcurrentSet(1, 2, 3)
+		assert.Equal(t, 2, s.ComputeIfAbsent(func(e int) bool { return e == 2 }, func() int { return 20 }))
+		assert.Equal(t, 40, s.ComputeIfAbsent(func(e int) bool { return e == 4 }, func() int { return 40 }))
+		assert.ElementsMatch(t, []int{1, 2, 3, 40}, s.ToSlice())
+	})
+
+	t.Run("set operations", func(t *testing.T) {
+		s := NewConcurrentSet(1, 2, 3, 4)
+		s.Retain(1, 2, 3)
+		assert.Equal(t, NewSet(1, 2, 3), s.Snapshot())
+		assert.Equal(t, NewSet(1, 2, 3, 5), s.Union(NewList(5)))
+		assert.Equal(t, NewSet(1), s.Subtract(NewList(2, 3)))
+
+		iter := s.MutableIterator()
+		for iter.HasNext() {
+			if e, _ := iter.Next(); e != 2 {
+				iter.Remove()
+			}
+		}
+		assert.Equal(t, []int{2}, s.ToSlice())
+
+		s.Clear()
+		assert.True(t, s.IsEmpty())
+	})
+}
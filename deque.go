@@ -0,0 +1,513 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/exp/slices"
+)
+
+// Deque is a double-ended queue, which supports adding and removing elements at both ends.
+type Deque[E comparable] interface {
+	List[E]
+
+	// AddFirst inserts the given element at the front of this deque.
+	AddFirst(element E)
+	// AddLast inserts the given element at the end of this deque.
+	AddLast(element E)
+	// PeekFirst returns the first element of this deque without removing it.
+	// If this deque is empty, it returns `false` as a second return value.
+	PeekFirst() (E, bool)
+	// PeekLast returns the last element of this deque without removing it.
+	// If this deque is empty, it returns `false` as a second return value.
+	PeekLast() (E, bool)
+	// RemoveFirst removes and returns the first element of this deque.
+	// If this deque is empty, it returns `false` as a second return value.
+	RemoveFirst() (E, bool)
+	// RemoveLast removes and returns the last element of this deque.
+	// If this deque is empty, it returns `false` as a second return value.
+	RemoveLast() (E, bool)
+}
+
+const dequeMinCapacity = 8
+
+// deque is a Deque backed by a ring buffer, which grows when it is full.
+// Adding and removing elements at both ends takes amortized O(1) time.
+type deque[E comparable] struct {
+	buf  []E
+	head int
+	size int
+	mod  *modCount
+}
+
+var _ Deque[int] = (*deque[int])(nil)
+
+// NewDeque returns a Deque containing the given elements.
+func NewDeque[E comparable](elements ...E) Deque[E] {
+	d := &deque[E]{buf: make([]E, max(dequeMinCapacity, len(elements)))}
+	copy(d.buf, elements)
+	d.size = len(elements)
+	return d
+}
+
+func (d *deque[E]) modification() *modCount {
+	if d.mod == nil {
+		d.mod = &modCount{}
+	}
+	return d.mod
+}
+
+// index returns the index in the buffer of the element at the given position of this deque.
+func (d *deque[E]) index(idx int) int {
+	return (d.head + idx) % len(d.buf)
+}
+
+func (d *deque[E]) at(idx int) E {
+	return d.buf[d.index(idx)]
+}
+
+func (d *deque[E]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	buf := make([]E, len(d.buf)*2)
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *deque[E]) set(idx int, e E) {
+	d.buf[d.index(idx)] = e
+}
+
+func (d *deque[E]) insert(idx int, e E) {
+	d.grow()
+	if idx < d.size-idx {
+		d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+		for i := 0; i < idx; i++ {
+			d.set(i, d.at(i+1))
+		}
+	} else {
+		for i := d.size; i > idx; i-- {
+			d.set(i, d.at(i-1))
+		}
+	}
+	d.set(idx, e)
+	d.size++
+	d.mod.increment()
+}
+
+func (d *deque[E]) removeAt(idx int) {
+	var zero E
+	if idx < d.size-1-idx {
+		for i := idx; i > 0; i-- {
+			d.set(i, d.at(i-1))
+		}
+		d.set(0, zero)
+		d.head = d.index(1)
+	} else {
+		for i := idx; i < d.size-1; i++ {
+			d.set(i, d.at(i+1))
+		}
+		d.set(d.size-1, zero)
+	}
+	d.size--
+	d.mod.increment()
+}
+
+// toList returns a list containing the elements of this deque in order.
+func (d *deque[E]) toList() *list[E] {
+	return &list[E]{elements: d.ToSlice()}
+}
+
+func (d *deque[E]) AddFirst(e E) {
+	d.insert(0, e)
+}
+
+func (d *deque[E]) AddLast(e E) {
+	d.insert(d.size, e)
+}
+
+func (d *deque[E]) PeekFirst() (E, bool) {
+	return d.ElementAt(0)
+}
+
+func (d *deque[E]) PeekLast() (E, bool) {
+	return d.ElementAt(d.size - 1)
+}
+
+func (d *deque[E]) RemoveFirst() (E, bool) {
+	e, ok := d.PeekFirst()
+	if ok {
+		d.removeAt(0)
+	}
+	return e, ok
+}
+
+func (d *deque[E]) RemoveLast() (E, bool) {
+	e, ok := d.PeekLast()
+	if ok {
+		d.removeAt(d.size - 1)
+	}
+	return e, ok
+}
+
+var _ Collection[int] = (*deque[int])(nil)
+
+func (d *deque[E]) Add(elements ...E) {
+	for _, e := range elements {
+		d.AddLast(e)
+	}
+}
+
+func (d *deque[E]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.size = 0
+	d.mod.increment()
+}
+
+func (d *deque[E]) IsEmpty() bool {
+	return d.size == 0
+}
+
+func (d *deque[E]) MutableIterator() MutableIterator[E] {
+	return newListIterator[E](d)
+}
+
+func (d *deque[E]) Remove(targets ...E) {
+	for _, t := range targets {
+		if idx := d.IndexOf(t); idx >= 0 {
+			d.removeAt(idx)
+		}
+	}
+}
+
+func (d *deque[E]) Retain(targets ...E) {
+	retained := 0
+	for i := range d.size {
+		if e := d.at(i); slices.Contains(targets, e) {
+			d.set(retained, e)
+			retained++
+		}
+	}
+	if retained == d.size {
+		return
+	}
+	var zero E
+	for i := retained; i < d.size; i++ {
+		d.set(i, zero)
+	}
+	d.size = retained
+	d.mod.increment()
+}
+
+func (d *deque[E]) Size() int {
+	return d.size
+}
+
+var _ Iterable[int] = (*deque[int])(nil)
+
+func (d *deque[E]) All(p func(e E) bool) bool {
+	if d.size == 0 {
+		return false
+	}
+	return d.IndexOfFirst(func(e E) bool { return !p(e) }) == -1
+}
+
+func (d *deque[E]) Any(p func(e E) bool) bool {
+	return d.IndexOfFirst(p) >= 0
+}
+
+func (d *deque[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](d.Iterator()))
+}
+
+func (d *deque[E]) Contains(e E) bool {
+	return d.IndexOf(e) >= 0
+}
+
+func (d *deque[E]) ContainsAll(elements ...E) bool {
+	return d.toList().ContainsAll(elements...)
+}
+
+func (d *deque[E]) ContainsAny(elements ...E) bool {
+	return d.toList().ContainsAny(elements...)
+}
+
+func (d *deque[E]) Count(p func(e E) bool) int {
+	count := 0
+	for i := range d.size {
+		if p(d.at(i)) {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *deque[E]) Distinct() Collection[E] {
+	return d.toList().Distinct()
+}
+
+func (d *deque[E]) Drop(n uint) Collection[E] {
+	return d.toList().Drop(n)
+}
+
+func (d *deque[E]) DropWhile(p func(e E) bool) Collection[E] {
+	return d.toList().DropWhile(p)
+}
+
+func (d *deque[E]) ElementAt(idx int) (E, bool) {
+	if idx < 0 || idx >= d.size {
+		var zero E
+		return zero, false
+	}
+	return d.at(idx), true
+}
+
+func (d *deque[E]) ElementAtOrElse(idx int, f func() E) E {
+	e, ok := d.ElementAt(idx)
+	if !ok {
+		return f()
+	}
+	return e
+}
+
+func (d *deque[E]) Equals(other Iterable[E]) bool {
+	return d.toList().Equals(other)
+}
+
+func (d *deque[E]) Filter(p func(e E) bool) Collection[E] {
+	return d.toList().Filter(p)
+}
+
+func (d *deque[E]) FilterIndexed(p func(idx int, e E) bool) Collection[E] {
+	return d.toList().FilterIndexed(p)
+}
+
+func (d *deque[E]) Find(p func(e E) bool) (E, bool) {
+	return d.ElementAt(d.IndexOfFirst(p))
+}
+
+func (d *deque[E]) FindLast(p func(e E) bool) (E, bool) {
+	return d.ElementAt(d.IndexOfLast(p))
+}
+
+func (d *deque[E]) ForEach(a func(e E)) {
+	d.ForEachIndexed(func(_ int, e E) {
+		a(e)
+	})
+}
+
+func (d *deque[E]) ForEachIndexed(a func(idx int, e E)) {
+	mod := d.modification()
+	expected := mod.count()
+	for i := range d.size {
+		a(i, d.at(i))
+		if err := mod.check(expected); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (d *deque[E]) Hash() uint64 {
+	return d.toList().Hash()
+}
+
+func (d *deque[E]) IndexOf(e E) int {
+	return d.IndexOfFirst(func(element E) bool { return element == e })
+}
+
+func (d *deque[E]) IndexOfFirst(p func(e E) bool) int {
+	for i := range d.size {
+		if p(d.at(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *deque[E]) IndexOfLast(p func(e E) bool) int {
+	for i := d.size - 1; i >= 0; i-- {
+		if p(d.at(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *deque[E]) Intersect(other Iterable[E]) Set[E] {
+	return d.toList().Intersect(other)
+}
+
+func (d *deque[E]) IsDisjointWith(other Iterable[E]) bool {
+	return d.toList().IsDisjointWith(other)
+}
+
+func (d *deque[E]) IsSubsetOf(other Iterable[E]) bool {
+	return d.toList().IsSubsetOf(other)
+}
+
+func (d *deque[E]) IsSupersetOf(other Iterable[E]) bool {
+	return d.toList().IsSupersetOf(other)
+}
+
+func (d *deque[E]) Iterator() Iterator[E] {
+	return newListIterator[E](d)
+}
+
+func (d *deque[E]) IntersectOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return d.toList().IntersectOrdered(other, semantics)
+}
+
+func (d *deque[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return d.toList().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (d *deque[E]) ListIterator() ListIterator[E] {
+	return newListIterator[E](d)
+}
+
+func (d *deque[E]) Map(t func(e E) E) Collection[E] {
+	return d.toList().Map(t)
+}
+
+func (d *deque[E]) MapIndexed(t func(idx int, e E) E) Collection[E] {
+	return d.toList().MapIndexed(t)
+}
+
+func (d *deque[E]) Minus(e ...E) Collection[E] {
+	return d.toList().Minus(e...)
+}
+
+func (d *deque[E]) None(p func(e E) bool) bool {
+	return d.IndexOfFirst(p) == -1
+}
+
+func (d *deque[E]) Partition(p func(e E) bool) (List[E], List[E]) {
+	return d.toList().Partition(p)
+}
+
+func (d *deque[E]) Plus(e ...E) Collection[E] {
+	return d.toList().Plus(e...)
+}
+
+func (d *deque[E]) Reversed() List[E] {
+	return d.toList().Reversed()
+}
+
+func (d *deque[E]) Shuffled() List[E] {
+	return d.toList().Shuffled()
+}
+
+func (d *deque[E]) Single(p func(e E) bool) (E, bool) {
+	return d.toList().Single(p)
+}
+
+func (d *deque[E]) Subtract(other Iterable[E]) Set[E] {
+	return d.toList().Subtract(other)
+}
+
+func (d *deque[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return d.toList().SymmetricDifference(other)
+}
+
+func (d *deque[E]) SubtractOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return d.toList().SubtractOrdered(other, semantics)
+}
+
+func (d *deque[E]) Take(n uint) Collection[E] {
+	return d.toList().Take(n)
+}
+
+func (d *deque[E]) TakeWhile(p func(e E) bool) Collection[E] {
+	return d.toList().TakeWhile(p)
+}
+
+func (d *deque[E]) UnionOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return d.toList().UnionOrdered(other, semantics)
+}
+
+func (d *deque[E]) ToList() List[E] {
+	return d.toList()
+}
+
+func (d *deque[E]) ToSet() Set[E] {
+	return NewSet(d.ToSlice()...)
+}
+
+func (d *deque[E]) ToSlice() []E {
+	res := make([]E, d.size)
+	for i := range d.size {
+		res[i] = d.at(i)
+	}
+	return res
+}
+
+func (d *deque[E]) Union(other Iterable[E]) Set[E] {
+	return d.toList().Union(other)
+}
+
+var (
+	_ json.Marshaler   = (*deque[int])(nil)
+	_ json.Unmarshaler = (*deque[int])(nil)
+)
+
+// MarshalJSON encodes this deque as a JSON array from the front to the back.
+func (d *deque[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(d.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of this deque with the decoded JSON array.
+func (d *deque[E]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONInto[E](d, data)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*deque[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*deque[int])(nil)
+	_ gob.GobEncoder             = (*deque[int])(nil)
+	_ gob.GobDecoder             = (*deque[int])(nil)
+)
+
+// MarshalBinary encodes this deque into a compact binary form from the front to the back.
+func (d *deque[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(d.ToSlice())
+}
+
+// UnmarshalBinary replaces the elements of this deque with the decoded binary form.
+func (d *deque[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryInto[E](d, data)
+}
+
+// GobEncode encodes this deque by MarshalBinary.
+func (d *deque[E]) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode decodes this deque by UnmarshalBinary.
+func (d *deque[E]) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*deque[int])(nil)
+	_ fmt.Stringer   = (*deque[int])(nil)
+	_ slog.LogValuer = (*deque[int])(nil)
+)
+
+func (d *deque[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "Deque", d.ToSlice())
+}
+
+func (d *deque[E]) String() string {
+	return fmt.Sprint(d)
+}
+
+func (d *deque[E]) LogValue() slog.Value {
+	return logCollection("Deque", d.ToSlice())
+}
@@ -0,0 +1,180 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque_AddFirstAndAddLast(t *testing.T) {
+	d := NewDeque[int]()
+	for i := range 10 {
+		d.AddFirst(-i)
+		d.AddLast(i)
+	}
+	assert.Equal(t, []int{-9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, d.ToSlice())
+	assert.Equal(t, 20, d.Size())
+}
+
+func TestDeque_RemoveFirstAndRemoveLast(t *testing.T) {
+	d := NewDeque(1, 2, 3)
+
+	e, ok := d.RemoveFirst()
+	assert.Equal(t, 1, e)
+	assert.True(t, ok)
+
+	e, ok = d.RemoveLast()
+	assert.Equal(t, 3, e)
+	assert.True(t, ok)
+
+	e, ok = d.RemoveLast()
+	assert.Equal(t, 2, e)
+	assert.True(t, ok)
+
+	e, ok = d.RemoveFirst()
+	assert.Equal(t, 0, e)
+	assert.False(t, ok)
+
+	e, ok = d.RemoveLast()
+	assert.Equal(t, 0, e)
+	assert.False(t, ok)
+	assert.True(t, d.IsEmpty())
+}
+
+func TestDeque_Peek(t *testing.T) {
+	tests := []struct {
+		name      string
+		deque     Deque[int]
+		wantFirst int
+		wantLast  int
+		wantOK    bool
+	}{
+		{
+			name:      "some elements",
+			deque:     NewDeque(1, 2, 3),
+			wantFirst: 1,
+			wantLast:  3,
+			wantOK:    true,
+		},
+		{
+			name:   "empty deque",
+			deque:  NewDeque[int](),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, ok := tt.deque.PeekFirst()
+			assert.Equal(t, tt.wantFirst, first)
+			assert.Equal(t, tt.wantOK, ok)
+			last, ok := tt.deque.PeekLast()
+			assert.Equal(t, tt.wantLast, last)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestDeque_WrapAround(t *testing.T) {
+	d := NewDeque[int]()
+	for i := range 6 {
+		d.AddLast(i)
+	}
+	for range 4 {
+		d.RemoveFirst()
+	}
+	for i := 6; i < 12; i++ {
+		d.AddLast(i)
+	}
+	assert.Equal(t, []int{4, 5, 6, 7, 8, 9, 10, 11}, d.ToSlice())
+
+	e, _ := d.ElementAt(3)
+	assert.Equal(t, 7, e)
+	assert.Equal(t, 5, d.IndexOf(9))
+
+	d.AddLast(12)
+	assert.Equal(t, []int{4, 5, 6, 7, 8, 9, 10, 11, 12}, d.ToSlice())
+}
+
+func TestDeque_Remove(t *testing.T) {
+	tests := []struct {
+		name    string
+		deque   Deque[int]
+		targets []int
+		want    []int
+	}{
+		{
+			name:    "remove near the front",
+			deque:   NewDeque(1, 2, 3, 4, 5),
+			targets: []int{2},
+			want:    []int{1, 3, 4, 5},
+		},
+		{
+			name:    "remove near the end",
+			deque:   NewDeque(1, 2, 3, 4, 5),
+			targets: []int{4},
+			want:    []int{1, 2, 3, 5},
+		},
+		{
+			name:    "duplicate entries should be removed for the num of times listed in args",
+			deque:   NewDeque(1, 2, 3, 3, 4, 3),
+			targets: []int{3, 3},
+			want:    []int{1, 2, 4, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.deque.Remove(tt.targets...)
+			assert.Equal(t, tt.want, tt.deque.ToSlice())
+		})
+	}
+}
+
+func TestDeque_Retain(t *testing.T) {
+	d := NewDeque(1, 2, 3, 3, 4, 3)
+	d.Retain(1, 3)
+	assert.Equal(t, []int{1, 3, 3, 3}, d.ToSlice())
+}
+
+func TestDeque_ListIterator(t *testing.T) {
+	d := NewDeque(1, 2, 4, 5)
+	iter := d.ListIterator()
+	for iter.HasNext() {
+		e, _ := iter.Next()
+		switch e {
+		case 2:
+			iter.Add(3)
+		case 5:
+			iter.Remove()
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, d.ToSlice())
+
+	iter = d.ListIterator()
+	iter.Next()
+	d.AddFirst(0)
+	_, ok := iter.Next()
+	assert.False(t, ok)
+	assert.ErrorIs(t, iter.Err(), ErrConcurrentModification)
+}
+
+func TestDeque_ListOperations(t *testing.T) {
+	d := NewDeque(1, 2, 3, 4)
+	d.AddFirst(0)
+
+	assert.Equal(t, NewList(0, 2, 4), d.Filter(func(e int) bool { return e%2 == 0 }))
+	assert.Equal(t, NewList(0, 1), d.Take(2))
+	assert.Equal(t, NewList(3, 4), d.Drop(3))
+	assert.Equal(t, NewList(4, 3, 2, 1, 0), d.Reversed())
+	assert.Equal(t, 2, d.Count(func(e int) bool { return e > 2 }))
+	assert.True(t, d.All(func(e int) bool { return e < 5 }))
+	assert.True(t, d.Contains(0))
+
+	e, ok := d.FindLast(func(e int) bool { return e < 3 })
+	assert.Equal(t, 2, e)
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, d.AsSequence().ToSlice())
+
+	d.Clear()
+	assert.True(t, d.IsEmpty())
+	assert.False(t, d.All(func(e int) bool { return e < 5 }))
+}
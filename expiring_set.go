@@ -0,0 +1,417 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ExpiringSet is a Set whose elements disappear once their time-to-live elapses,
+// e.g. for idempotency keys and rate-limiting. It is safe for concurrent use.
+//
+// Expired elements are removed lazily when they are accessed, or periodically by a janitor started by StartJanitor.
+// Either way, methods of this set never return expired elements.
+type ExpiringSet[E comparable] interface {
+	Set[E]
+
+	// AddWithTTL adds the given elements, which expire after the given time-to-live.
+	// Adding an element already in this set resets its expiration.
+	// If ttl is not positive, the elements never expire.
+	AddWithTTL(ttl time.Duration, elements ...E)
+	// ExpiresAt returns the time when the given element expires.
+	// It returns the zero time for an element that never expires,
+	// and `false` as a second return value if the element is not in this set.
+	ExpiresAt(element E) (time.Time, bool)
+	// RemoveExpired removes all expired elements and returns the number of removed elements.
+	RemoveExpired() int
+	// StartJanitor starts a goroutine that calls RemoveExpired at the given interval, and returns a function to stop it.
+	StartJanitor(interval time.Duration) (stop func())
+	// Touch resets the expiration of the given element as if it has been added again with the same time-to-live.
+	// It returns `false` if the element is not in this set.
+	Touch(element E) bool
+}
+
+type expiringEntry struct {
+	ttl       time.Duration
+	expiresAt time.Time
+}
+
+type expiringSet[E comparable] struct {
+	mu    sync.Mutex
+	m     map[E]expiringEntry
+	ttl   time.Duration
+	clock Clock
+}
+
+var _ ExpiringSet[int] = (*expiringSet[int])(nil)
+
+// NewExpiringSet returns an ExpiringSet containing the given elements, where Add uses the given time-to-live
+// and the given clock measures it. If clock is nil, SystemClock is used.
+func NewExpiringSet[E comparable](ttl time.Duration, clock Clock, elements ...E) ExpiringSet[E] {
+	if clock == nil {
+		clock = SystemClock
+	}
+	s := &expiringSet[E]{m: make(map[E]expiringEntry), ttl: ttl, clock: clock}
+	s.AddWithTTL(ttl, elements...)
+	return s
+}
+
+func (s *expiringSet[E]) expired(entry expiringEntry, now time.Time) bool {
+	return entry.ttl > 0 && !now.Before(entry.expiresAt)
+}
+
+// lookup returns the entry of the given element, removing it if it has expired. The caller must hold mu.
+func (s *expiringSet[E]) lookup(e E) (expiringEntry, bool) {
+	entry, ok := s.m[e]
+	if !ok {
+		return entry, false
+	}
+	if s.expired(entry, s.clock.Now()) {
+		delete(s.m, e)
+		return entry, false
+	}
+	return entry, true
+}
+
+// removeExpired removes all expired elements. The caller must hold mu.
+func (s *expiringSet[E]) removeExpired() int {
+	now := s.clock.Now()
+	n := 0
+	for e, entry := range s.m {
+		if s.expired(entry, now) {
+			delete(s.m, e)
+			n++
+		}
+	}
+	return n
+}
+
+func (s *expiringSet[E]) snapshot() *set[E] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	m := make(map[E]struct{}, len(s.m))
+	for e := range s.m {
+		m[e] = struct{}{}
+	}
+	return &set[E]{m: m}
+}
+
+func (s *expiringSet[E]) AddWithTTL(ttl time.Duration, elements ...E) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiresAt := s.clock.Now().Add(ttl)
+	for _, e := range elements {
+		s.m[e] = expiringEntry{ttl: ttl, expiresAt: expiresAt}
+	}
+}
+
+func (s *expiringSet[E]) ExpiresAt(e E) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.lookup(e)
+	if !ok || entry.ttl <= 0 {
+		return time.Time{}, ok
+	}
+	return entry.expiresAt, true
+}
+
+func (s *expiringSet[E]) RemoveExpired() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeExpired()
+}
+
+func (s *expiringSet[E]) StartJanitor(interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.RemoveExpired()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
+
+func (s *expiringSet[E]) Touch(e E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.lookup(e)
+	if !ok {
+		return false
+	}
+	entry.expiresAt = s.clock.Now().Add(entry.ttl)
+	s.m[e] = entry
+	return true
+}
+
+var _ Collection[int] = (*expiringSet[int])(nil)
+
+// Add adds the given elements with the time-to-live of this set.
+// Adding an element already in this set resets its expiration.
+func (s *expiringSet[E]) Add(elements ...E) {
+	s.AddWithTTL(s.ttl, elements...)
+}
+
+func (s *expiringSet[E]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.m)
+}
+
+func (s *expiringSet[E]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *expiringSet[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(s.ToSlice(), func(e E) {
+		s.Remove(e)
+	}, nil)
+}
+
+func (s *expiringSet[E]) Remove(targets ...E) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range targets {
+		delete(s.m, t)
+	}
+}
+
+func (s *expiringSet[E]) Retain(targets ...E) {
+	retained := NewSet(targets...)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for e := range s.m {
+		if !retained.Contains(e) {
+			delete(s.m, e)
+		}
+	}
+}
+
+func (s *expiringSet[E]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired()
+	return len(s.m)
+}
+
+var _ Iterable[int] = (*expiringSet[int])(nil)
+
+func (s *expiringSet[E]) All(p func(e E) bool) bool {
+	return s.snapshot().All(p)
+}
+
+func (s *expiringSet[E]) Any(p func(e E) bool) bool {
+	return s.snapshot().Any(p)
+}
+
+// AsSequence returns a sequence over a snapshot of the elements that have not expired.
+func (s *expiringSet[E]) AsSequence() Sequence[E] {
+	return s.snapshot().AsSequence()
+}
+
+func (s *expiringSet[E]) Contains(e E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.lookup(e)
+	return ok
+}
+
+func (s *expiringSet[E]) ContainsAll(elements ...E) bool {
+	return s.snapshot().ContainsAll(elements...)
+}
+
+func (s *expiringSet[E]) ContainsAny(elements ...E) bool {
+	return s.snapshot().ContainsAny(elements...)
+}
+
+func (s *expiringSet[E]) Count(p func(e E) bool) int {
+	return s.snapshot().Count(p)
+}
+
+func (s *expiringSet[E]) Distinct() Collection[E] {
+	return s.snapshot()
+}
+
+func (s *expiringSet[E]) Equals(other Set[E]) bool {
+	return s.snapshot().Equals(other)
+}
+
+func (s *expiringSet[E]) Filter(p func(e E) bool) Collection[E] {
+	return s.snapshot().Filter(p)
+}
+
+func (s *expiringSet[E]) Find(p func(e E) bool) (E, bool) {
+	return s.snapshot().Find(p)
+}
+
+func (s *expiringSet[E]) ForEach(a func(e E)) {
+	s.snapshot().ForEach(a)
+}
+
+func (s *expiringSet[E]) Hash() uint64 {
+	return s.snapshot().Hash()
+}
+
+func (s *expiringSet[E]) Intersect(other Iterable[E]) Set[E] {
+	return s.snapshot().Intersect(other)
+}
+
+func (s *expiringSet[E]) IsDisjointWith(other Iterable[E]) bool {
+	return s.snapshot().IsDisjointWith(other)
+}
+
+func (s *expiringSet[E]) IsSubsetOf(other Iterable[E]) bool {
+	return s.snapshot().IsSubsetOf(other)
+}
+
+func (s *expiringSet[E]) IsSupersetOf(other Iterable[E]) bool {
+	return s.snapshot().IsSupersetOf(other)
+}
+
+func (s *expiringSet[E]) Iterator() Iterator[E] {
+	return s.MutableIterator()
+}
+
+func (s *expiringSet[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return s.snapshot().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (s *expiringSet[E]) Map(t func(e E) E) Collection[E] {
+	return s.snapshot().Map(t)
+}
+
+func (s *expiringSet[E]) Minus(e ...E) Collection[E] {
+	return s.snapshot().Minus(e...)
+}
+
+func (s *expiringSet[E]) None(p func(e E) bool) bool {
+	return s.snapshot().None(p)
+}
+
+func (s *expiringSet[E]) Plus(e ...E) Collection[E] {
+	return s.snapshot().Plus(e...)
+}
+
+func (s *expiringSet[E]) Single(p func(e E) bool) (E, bool) {
+	return s.snapshot().Single(p)
+}
+
+func (s *expiringSet[E]) Subtract(other Iterable[E]) Set[E] {
+	return s.snapshot().Subtract(other)
+}
+
+func (s *expiringSet[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return s.snapshot().SymmetricDifference(other)
+}
+
+func (s *expiringSet[E]) ToList() List[E] {
+	return s.snapshot().ToList()
+}
+
+func (s *expiringSet[E]) ToSet() Set[E] {
+	return s.snapshot()
+}
+
+func (s *expiringSet[E]) ToSlice() []E {
+	return s.snapshot().ToSlice()
+}
+
+func (s *expiringSet[E]) Union(other Iterable[E]) Set[E] {
+	return s.snapshot().Union(other)
+}
+
+// replaceAll replaces the elements of this set with the given elements.
+func (s *expiringSet[E]) replaceAll(elements []E) error {
+	s.mu.Lock()
+	clear(s.m)
+	s.mu.Unlock()
+	s.Add(elements...)
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*expiringSet[int])(nil)
+	_ json.Unmarshaler = (*expiringSet[int])(nil)
+)
+
+// MarshalJSON encodes the elements that have not expired as a JSON array in arbitrary order.
+// Their expiration is not encoded.
+func (s *expiringSet[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of this set with the decoded JSON array,
+// which expire after the time-to-live of this set.
+func (s *expiringSet[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*expiringSet[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*expiringSet[int])(nil)
+	_ gob.GobEncoder             = (*expiringSet[int])(nil)
+	_ gob.GobDecoder             = (*expiringSet[int])(nil)
+)
+
+// MarshalBinary encodes the elements that have not expired into a compact binary form. Their expiration is not encoded.
+func (s *expiringSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(s.ToSlice())
+}
+
+// UnmarshalBinary replaces the elements of this set with the decoded binary form,
+// which expire after the time-to-live of this set.
+func (s *expiringSet[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+// GobEncode encodes this set by MarshalBinary.
+func (s *expiringSet[E]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode decodes this set by UnmarshalBinary.
+func (s *expiringSet[E]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*expiringSet[int])(nil)
+	_ fmt.Stringer   = (*expiringSet[int])(nil)
+	_ slog.LogValuer = (*expiringSet[int])(nil)
+)
+
+func (s *expiringSet[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "ExpiringSet", sortedForDisplay(s.ToSlice()))
+}
+
+func (s *expiringSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *expiringSet[E]) LogValue() slog.Value {
+	return logCollection("ExpiringSet", sortedForDisplay(s.ToSlice()))
+}
@@ -0,0 +1,112 @@
+package kol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiringSet_Expiry(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiringSet(time.Minute, clock, "a", "b")
+	s.AddWithTTL(2*time.Minute, "c")
+	s.AddWithTTL(0, "d")
+
+	clock.Advance(time.Minute)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "Contains expired", got: s.Contains("a"), want: false},
+		{name: "Contains alive", got: s.Contains("c"), want: true},
+		{name: "Size", got: s.Size(), want: 2},
+		{name: "ToSlice", got: len(s.ToSlice()), want: 2},
+		{name: "Count", got: s.Count(func(string) bool { return true }), want: 2},
+		{name: "Any", got: s.Any(func(e string) bool { return e == "b" }), want: false},
+		{name: "Filter", got: s.Filter(func(e string) bool { return e != "d" }).ToSlice(), want: []string{"c"}},
+		{name: "Union", got: s.Union(NewList("e")).Size(), want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+	assert.ElementsMatch(t, []string{"c", "d"}, s.AsSequence().ToSlice())
+}
+
+func TestExpiringSet_ExpiresAt(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	s := NewExpiringSet(time.Minute, clock, "a")
+	s.AddWithTTL(-1, "b")
+
+	at, ok := s.ExpiresAt("a")
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(time.Minute), at)
+
+	at, ok = s.ExpiresAt("b")
+	assert.True(t, ok)
+	assert.True(t, at.IsZero())
+
+	_, ok = s.ExpiresAt("c")
+	assert.False(t, ok)
+}
+
+func TestExpiringSet_Touch(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiringSet(time.Minute, clock, "a", "b")
+	clock.Advance(30 * time.Second)
+	assert.True(t, s.Touch("a"))
+	s.Add("b")
+	clock.Advance(59 * time.Second)
+	assert.ElementsMatch(t, []string{"a", "b"}, s.ToSlice())
+	clock.Advance(time.Second)
+	assert.False(t, s.Touch("a"))
+	assert.True(t, s.IsEmpty())
+}
+
+func TestExpiringSet_RemoveExpired(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiringSet(time.Minute, clock, "a", "b")
+	s.AddWithTTL(time.Hour, "c")
+	assert.Equal(t, 0, s.RemoveExpired())
+	clock.Advance(time.Minute)
+	assert.Equal(t, 2, s.RemoveExpired())
+	s.Remove("c")
+	assert.True(t, s.IsEmpty())
+}
+
+func TestExpiringSet_StartJanitor(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiringSet(time.Minute, clock, "a").(*expiringSet[string]) //nolint:forcetypeassert
+	stop := s.StartJanitor(time.Millisecond)
+	defer stop()
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.m) == 0
+	}, time.Second, time.Millisecond)
+	stop()
+}
+
+func TestExpiringSet_MutableIterator(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiringSet(time.Minute, clock, 1, 2, 3)
+	it := s.MutableIterator()
+	for it.HasNext() {
+		e, _ := it.Next()
+		if e%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int{2}, s.ToSlice())
+
+	s.Retain(3)
+	assert.True(t, s.IsEmpty())
+}
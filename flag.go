@@ -0,0 +1,242 @@
+package kol
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// FlagConfig configures how FlagList and FlagSet parse flag values.
+type FlagConfig[E comparable] struct {
+	// Parse parses an element, e.g. strconv.Atoi.
+	// If nil, booleans, numbers and strings are parsed by strconv, implementations of encoding.TextUnmarshaler
+	// by UnmarshalText, and pointers to them as the values they point to.
+	Parse func(s string) (E, error)
+	// Separators are the characters separating elements in a value, e.g. "," for `--tag a,b`.
+	// Elements are trimmed of surrounding white space, and empty elements are ignored.
+	// If empty, each value is a single element.
+	Separators string
+	// Distinct makes FlagList drop elements that it already contains.
+	Distinct bool
+	// Compare makes FlagList keep its elements sorted by this function.
+	Compare func(a, b E) int
+}
+
+// parse splits the given value by the separators and parses each element.
+func (c FlagConfig[E]) parse(value string) ([]E, error) {
+	parts := []string{value}
+	if c.Separators != "" {
+		parts = strings.FieldsFunc(value, func(r rune) bool {
+			return strings.ContainsRune(c.Separators, r)
+		})
+	}
+	parse := c.Parse
+	if parse == nil {
+		parse = parseFlagElement[E]
+	}
+	elements := make([]E, 0, len(parts))
+	for _, p := range parts {
+		if c.Separators != "" {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+		}
+		e, err := parse(p)
+		if err != nil {
+			return nil, fmt.Errorf("kol: invalid element %q: %w", p, err)
+		}
+		elements = append(elements, e)
+	}
+	return elements, nil
+}
+
+// join formats the given elements separated by the first separator, or "," if there are no separators.
+func (c FlagConfig[E]) join(elements []E) string {
+	sep := ","
+	if c.Separators != "" {
+		sep = c.Separators[:1]
+	}
+	parts := make([]string, len(elements))
+	for i, e := range elements {
+		parts[i] = fmt.Sprint(e)
+	}
+	return strings.Join(parts, sep)
+}
+
+// parseFlagElement parses an element by parseScalar, the default parser of FlagConfig.
+func parseFlagElement[E comparable](s string) (E, error) {
+	var e E
+	err := parseScalar(s, reflect.ValueOf(&e).Elem())
+	return e, err
+}
+
+// FlagList is a flag.Value collecting repeated or separated flag values into a List, e.g. `--port 80 --port 443,8080`.
+// The initial elements are defaults: the first value given replaces them, and later values are appended.
+//
+// It also implements encoding.TextUnmarshaler, which replaces all elements, for loaders of environment variables.
+// The zero value is an empty FlagList parsing each value as a single element by the default parser of FlagConfig.
+type FlagList[E comparable] struct {
+	elements List[E]
+	config   FlagConfig[E]
+	set      bool
+}
+
+var (
+	_ flag.Getter              = (*FlagList[int])(nil)
+	_ encoding.TextMarshaler   = (*FlagList[int])(nil)
+	_ encoding.TextUnmarshaler = (*FlagList[int])(nil)
+)
+
+// NewFlagList returns a FlagList configured by the given config, containing the given default elements.
+func NewFlagList[E comparable](config FlagConfig[E], defaults ...E) *FlagList[E] {
+	l := &FlagList[E]{config: config}
+	l.add(defaults)
+	return l
+}
+
+func (l *FlagList[E]) add(elements []E) {
+	list := l.Elements()
+	for _, e := range elements {
+		if !l.config.Distinct || !list.Contains(e) {
+			list.Add(e)
+		}
+	}
+	if l.config.Compare != nil {
+		sorted := list.ToSlice()
+		slices.SortStableFunc(sorted, l.config.Compare)
+		list.Clear()
+		list.Add(sorted...)
+	}
+}
+
+// Elements returns the list of the elements.
+func (l *FlagList[E]) Elements() List[E] {
+	if l.elements == nil {
+		l.elements = NewList[E]()
+	}
+	return l.elements
+}
+
+// Get returns the list of the elements for flag.Getter.
+func (l *FlagList[E]) Get() any {
+	return l.Elements()
+}
+
+// Set parses the given flag value and adds the elements, replacing the defaults on the first call.
+func (l *FlagList[E]) Set(value string) error {
+	elements, err := l.config.parse(value)
+	if err != nil {
+		return err
+	}
+	if !l.set {
+		l.set = true
+		l.Elements().Clear()
+	}
+	l.add(elements)
+	return nil
+}
+
+// String returns the elements joined by the first separator.
+func (l *FlagList[E]) String() string {
+	if l == nil || l.elements == nil {
+		return ""
+	}
+	return l.config.join(l.elements.ToSlice())
+}
+
+// MarshalText returns the elements joined by the first separator.
+func (l *FlagList[E]) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText replaces the elements with the elements parsed from the given text.
+func (l *FlagList[E]) UnmarshalText(text []byte) error {
+	elements, err := l.config.parse(string(text))
+	if err != nil {
+		return err
+	}
+	l.set = true
+	l.Elements().Clear()
+	l.add(elements)
+	return nil
+}
+
+// FlagSet is a flag.Value collecting distinct values of repeated or separated flags into a Set,
+// e.g. `--tag a --tag b,c`. The initial elements are defaults: the first value given replaces them,
+// and later values are added. String formats the elements in sorted order.
+//
+// It also implements encoding.TextUnmarshaler, which replaces all elements, for loaders of environment variables.
+// The Distinct and Compare options of FlagConfig are ignored.
+// The zero value is an empty FlagSet parsing each value as a single element by the default parser of FlagConfig.
+type FlagSet[E comparable] struct {
+	elements Set[E]
+	config   FlagConfig[E]
+	set      bool
+}
+
+var (
+	_ flag.Getter              = (*FlagSet[int])(nil)
+	_ encoding.TextMarshaler   = (*FlagSet[int])(nil)
+	_ encoding.TextUnmarshaler = (*FlagSet[int])(nil)
+)
+
+// NewFlagSet returns a FlagSet configured by the given config, containing the given default elements.
+func NewFlagSet[E comparable](config FlagConfig[E], defaults ...E) *FlagSet[E] {
+	return &FlagSet[E]{elements: NewSet(defaults...), config: config}
+}
+
+// Elements returns the set of the elements.
+func (s *FlagSet[E]) Elements() Set[E] {
+	if s.elements == nil {
+		s.elements = NewSet[E]()
+	}
+	return s.elements
+}
+
+// Get returns the set of the elements for flag.Getter.
+func (s *FlagSet[E]) Get() any {
+	return s.Elements()
+}
+
+// Set parses the given flag value and adds the elements, replacing the defaults on the first call.
+func (s *FlagSet[E]) Set(value string) error {
+	elements, err := s.config.parse(value)
+	if err != nil {
+		return err
+	}
+	if !s.set {
+		s.set = true
+		s.Elements().Clear()
+	}
+	s.Elements().Add(elements...)
+	return nil
+}
+
+// String returns the elements in sorted order joined by the first separator.
+func (s *FlagSet[E]) String() string {
+	if s == nil || s.elements == nil {
+		return ""
+	}
+	return s.config.join(sortedForDisplay(s.elements.ToSlice()))
+}
+
+// MarshalText returns the elements in sorted order joined by the first separator.
+func (s *FlagSet[E]) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText replaces the elements with the elements parsed from the given text.
+func (s *FlagSet[E]) UnmarshalText(text []byte) error {
+	elements, err := s.config.parse(string(text))
+	if err != nil {
+		return err
+	}
+	s.set = true
+	s.Elements().Clear()
+	s.Elements().Add(elements...)
+	return nil
+}
@@ -0,0 +1,143 @@
+package kol
+
+import (
+	"cmp"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func TestFlagList(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   FlagConfig[int]
+		defaults []int
+		args     []string
+		want     []int
+		wantStr  string
+	}{
+		{
+			name:     "defaults",
+			config:   FlagConfig[int]{Parse: strconv.Atoi, Separators: ","},
+			defaults: []int{80},
+			want:     []int{80},
+			wantStr:  "80",
+		},
+		{
+			name:     "repeated and separated",
+			config:   FlagConfig[int]{Parse: strconv.Atoi, Separators: ","},
+			defaults: []int{80},
+			args:     []string{"-port", "443", "-port", "8080, 443,,9090"},
+			want:     []int{443, 8080, 443, 9090},
+			wantStr:  "443,8080,443,9090",
+		},
+		{
+			name:    "distinct",
+			config:  FlagConfig[int]{Parse: strconv.Atoi, Separators: ",;", Distinct: true},
+			args:    []string{"-port", "3;1,3", "-port", "1"},
+			want:    []int{3, 1},
+			wantStr: "3,1",
+		},
+		{
+			name:    "sorted",
+			config:  FlagConfig[int]{Parse: strconv.Atoi, Separators: " ", Compare: cmp.Compare[int]},
+			args:    []string{"-port", "3 1", "-port", "2"},
+			want:    []int{1, 2, 3},
+			wantStr: "1 2 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ports := NewFlagList(tt.config, tt.defaults...)
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.Var(ports, "port", "ports to listen")
+			assert.NoError(t, fs.Parse(tt.args))
+			assert.Equal(t, tt.want, ports.Elements().ToSlice())
+			assert.Equal(t, tt.wantStr, ports.String())
+			assert.Equal(t, ports.Elements(), fs.Lookup("port").Value.(flag.Getter).Get())
+		})
+	}
+}
+
+func TestFlagList_Error(t *testing.T) {
+	ports := NewFlagList(FlagConfig[int]{Parse: strconv.Atoi, Separators: ","})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(ports, "port", "ports to listen")
+	err := fs.Parse([]string{"-port", "80,http"})
+	assert.ErrorContains(t, err, `invalid value "80,http" for flag -port: kol: invalid element "http"`)
+	assert.ErrorIs(t, ports.Set("http"), strconv.ErrSyntax)
+}
+
+func TestFlag_ZeroValue(t *testing.T) {
+	var (
+		tags  FlagSet[string]
+		ports FlagList[uint16]
+		until FlagList[time.Time]
+		empty FlagList[int]
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&tags, "tag", "tags")
+	fs.Var(&ports, "port", "ports to listen")
+	fs.Var(&until, "until", "deadlines")
+	fs.Var(&empty, "n", "numbers")
+	assert.NoError(t, fs.Parse([]string{
+		"--tag", "b", "--tag", "a,c", "--tag", "b", "--port", "80", "--port", "443", "--until", "2026-01-02T03:04:05Z",
+	}))
+
+	assert.ElementsMatch(t, []string{"b", "a,c"}, tags.Elements().ToSlice())
+	assert.Equal(t, "a,c,b", tags.String())
+	assert.Equal(t, []uint16{80, 443}, ports.Elements().ToSlice())
+	assert.Equal(t, []time.Time{time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}, until.Elements().ToSlice())
+	assert.True(t, empty.Elements().IsEmpty())
+	assert.Equal(t, "", empty.String())
+
+	assert.ErrorContains(t, fs.Parse([]string{"--port", "-1"}), `invalid value "-1" for flag -port: kol: invalid element "-1"`)
+	assert.NoError(t, tags.UnmarshalText([]byte("x")))
+	assert.Equal(t, []string{"x"}, tags.Elements().ToSlice())
+}
+
+func TestFlagList_NoSeparators(t *testing.T) {
+	names := NewFlagList(FlagConfig[string]{Parse: parseString})
+	assert.NoError(t, names.Set(" a, b "))
+	assert.Equal(t, []string{" a, b "}, names.Elements().ToSlice())
+}
+
+func TestFlagList_UnmarshalText(t *testing.T) {
+	ports := NewFlagList(FlagConfig[int]{Parse: strconv.Atoi, Separators: ","}, 80)
+	assert.NoError(t, ports.Set("1"))
+	assert.NoError(t, ports.UnmarshalText([]byte("443,8443")))
+	assert.Equal(t, []int{443, 8443}, ports.Elements().ToSlice())
+	text, err := ports.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "443,8443", string(text))
+	assert.Error(t, ports.UnmarshalText([]byte("x")))
+}
+
+func TestFlagSet(t *testing.T) {
+	tags := NewFlagSet(FlagConfig[string]{Parse: parseString, Separators: ","}, "default")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(tags, "tag", "tags")
+	var usage strings.Builder
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	assert.Contains(t, usage.String(), "(default default)")
+
+	assert.NoError(t, fs.Parse([]string{"--tag", "b", "--tag", "c,a,b"}))
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, tags.Elements().ToSlice())
+	assert.Equal(t, "a,b,c", tags.String())
+
+	assert.NoError(t, tags.UnmarshalText([]byte("x")))
+	assert.Equal(t, []string{"x"}, tags.Elements().ToSlice())
+	assert.Error(t, NewFlagSet(FlagConfig[int]{Parse: strconv.Atoi}).Set("x"))
+}
@@ -0,0 +1,146 @@
+package kol
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// formatCollection writes the given elements of a collection to f for fmt.Formatter.
+//
+// Elements are formatted with the same verb, flags and width, like fmt formats a slice.
+// The precision of %v limits the number of elements, and the rest are summarized as "...and N more",
+// while the precision of other verbs applies to each element.
+// The + flag of %v prefixes the elements with the type name and the size of the collection.
+func formatCollection[E comparable](f fmt.State, verb rune, name string, elements []E) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "%s size=%d ", typeName[E](name), len(elements))
+	}
+	formatElements(f, verb, elements)
+}
+
+// formatElements writes the given elements to f like formatCollection, without the type name and the size.
+func formatElements[E comparable](f fmt.State, verb rune, elements []E) {
+	format, limit := elementFormat(f, verb)
+	fmt.Fprint(f, "[")
+	for i, e := range elements {
+		if i > 0 {
+			fmt.Fprint(f, ", ")
+		}
+		if i == limit {
+			fmt.Fprintf(f, "...and %d more", len(elements)-limit)
+			break
+		}
+		fmt.Fprintf(f, format, e)
+	}
+	fmt.Fprint(f, "]")
+}
+
+// formatMap writes the given entries of a map to f for fmt.Formatter as `{k1: v1, k2: v2}`,
+// in the order of the keys sorted by sortedForDisplay.
+// Keys and values are formatted like the elements of formatCollection,
+// and the precision of %v limits the number of keys.
+// The + flag of %v prefixes the entries with the given type name and size.
+func formatMap[K comparable, V any](f fmt.State, verb rune, typ string, size int, entries map[K]V) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "%s size=%d ", typ, size)
+	}
+	format, limit := elementFormat(f, verb)
+	fmt.Fprint(f, "{")
+	for i, k := range sortedForDisplay(maps.Keys(entries)) {
+		if i > 0 {
+			fmt.Fprint(f, ", ")
+		}
+		if i == limit {
+			fmt.Fprintf(f, "...and %d more", len(entries)-limit)
+			break
+		}
+		fmt.Fprintf(f, format+": "+format, k, entries[k])
+	}
+	fmt.Fprint(f, "}")
+}
+
+// displayList is a slice formatted like a collection without the type name and the size,
+// which is used as values of formatMap.
+type displayList[E comparable] []E
+
+func (l displayList[E]) Format(f fmt.State, verb rune) {
+	formatElements(f, verb, l)
+}
+
+// elementFormat returns the format of each element for the given state and verb,
+// and the limit of the number of elements given by the precision of %v, or -1 if there is no limit.
+func elementFormat(f fmt.State, verb rune) (string, int) {
+	var format strings.Builder
+	format.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			format.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		format.WriteString(strconv.Itoa(width))
+	}
+	limit := -1
+	if precision, ok := f.Precision(); ok {
+		if verb == 'v' {
+			limit = precision
+		} else {
+			format.WriteByte('.')
+			format.WriteString(strconv.Itoa(precision))
+		}
+	}
+	format.WriteRune(verb)
+	return format.String(), limit
+}
+
+// formatWrapped writes the collection embedded in a wrapper type such as JSONList to f for fmt.Formatter.
+// A nil collection is formatted as an empty collection of the given type name,
+// so that the zero value of the wrapper type can be formatted.
+func formatWrapped[E comparable](f fmt.State, verb rune, name string, c Collection[E]) {
+	if c == nil {
+		formatCollection[E](f, verb, name, nil)
+		return
+	}
+	c.Format(f, verb)
+}
+
+// sortedForDisplay returns the given elements of an un-ordered collection sorted in a deterministic order.
+// Booleans, numbers and strings are sorted by their values, and other elements are sorted by their string forms.
+func sortedForDisplay[E comparable](elements []E) []E {
+	slices.SortStableFunc(elements, compareForDisplay[E])
+	return elements
+}
+
+func compareForDisplay[E comparable](a, b E) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != vb.Kind() {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	switch va.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(va.Bool()), boolToInt(vb.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
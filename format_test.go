@@ -0,0 +1,103 @@
+package kol
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection_Format(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection fmt.Formatter
+		format     string
+		want       string
+	}{
+		{name: "list", collection: NewList(3, 1, 2), format: "%v", want: "[3, 1, 2]"},
+		{name: "empty list", collection: NewList[int](), format: "%v", want: "[]"},
+		{name: "plus", collection: NewList(3, 1, 2), format: "%+v", want: "List[int] size=3 [3, 1, 2]"},
+		{name: "precision", collection: NewList(1, 2, 3, 4, 5), format: "%.2v", want: "[1, 2, ...and 3 more]"},
+		{name: "precision over size", collection: NewList(1, 2), format: "%.10v", want: "[1, 2]"},
+		{name: "plus and precision", collection: NewList("a", "b"), format: "%+.1v", want: "List[string] size=2 [a, ...and 1 more]"},
+		{name: "element verb", collection: NewList("a", "b"), format: "%q", want: `["a", "b"]`},
+		{name: "element precision", collection: NewList(1.5, 2), format: "%.2f", want: "[1.50, 2.00]"},
+		{name: "element width", collection: NewList(1, 22), format: "%3d", want: "[  1,  22]"},
+		{name: "element plus", collection: NewList(NewPair("a", 1)), format: "%v", want: "[(a, 1)]"},
+		{name: "set", collection: NewSet(10, 9, 1, 2), format: "%v", want: "[1, 2, 9, 10]"},
+		{name: "set of strings", collection: NewSet("b", "c", "a"), format: "%.2v", want: "[a, b, ...and 1 more]"},
+		{name: "set of pairs", collection: NewSet(NewPair("b", 1), NewPair("a", 2)), format: "%v", want: "[(a, 2), (b, 1)]"},
+		{name: "set plus", collection: NewSet(true, false), format: "%+v", want: "Set[bool] size=2 [false, true]"},
+		{name: "deque", collection: NewDeque(2, 1), format: "%+v", want: "Deque[int] size=2 [2, 1]"},
+		{name: "ring buffer", collection: NewRingBuffer[int](2, OverflowOverwrite), format: "%+v", want: "RingBuffer[int] size=0 []"},
+		{name: "priority queue", collection: NewPriorityQueue(3, 1, 2), format: "%v", want: "[1, 2, 3]"},
+		{name: "multiset", collection: NewMultiset(2, 1, 2), format: "%+v", want: "Multiset[int] size=3 [1, 2, 2]"},
+		{name: "blocking queue", collection: NewBlockingQueue[int](1), format: "%+v", want: "BlockingQueue[int] size=0 []"},
+		{name: "concurrent list", collection: NewConcurrentList(2, 1), format: "%+v", want: "ConcurrentList[int] size=2 [2, 1]"},
+		{name: "concurrent set", collection: NewConcurrentSet(2, 1), format: "%+v", want: "ConcurrentSet[int] size=2 [1, 2]"},
+		{name: "expiring set", collection: NewExpiringSet(time.Hour, nil, 2, 1), format: "%v", want: "[1, 2]"},
+		{name: "synchronized list", collection: SynchronizedList(NewList(2, 1)), format: "%+v", want: "SynchronizedList[int] size=2 [2, 1]"},
+		{name: "synchronized set", collection: SynchronizedSet(NewSet(2, 1)), format: "%+v", want: "SynchronizedSet[int] size=2 [1, 2]"},
+		{name: "frozen list", collection: NewFrozenList(2, 1), format: "%+v", want: "FrozenList[int] size=2 [2, 1]"},
+		{name: "frozen set", collection: NewFrozenSet(2, 1, 3), format: "%.1v", want: "[1, ...and 2 more]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.collection))
+		})
+	}
+}
+
+func TestMap_Format(t *testing.T) {
+	bimap, _ := NewBiMapFromPairs(NewList(NewPair("b", 2), NewPair("a", 1), NewPair("c", 3)))
+	listMultimap := NewListMultimap[string, string]()
+	listMultimap.PutAll("b", "y", "x")
+	listMultimap.Put("a", "z")
+	setMultimap := NewSetMultimap[string, int]()
+	setMultimap.PutAll("a", 3, 1)
+	clock := newFakeClock()
+	c := NewCache(CacheConfig[string, float64]{TTL: time.Minute, Clock: clock})
+	c.Put("expired", 0)
+	clock.Advance(time.Minute)
+	c.Put("b", 2)
+	c.Put("a", 1.5)
+
+	tests := []struct {
+		name   string
+		m      fmt.Formatter
+		format string
+		want   string
+	}{
+		{name: "bimap", m: bimap, format: "%v", want: "{a: 1, b: 2, c: 3}"},
+		{name: "bimap plus", m: bimap, format: "%+v", want: "BiMap[string,int] size=3 {a: 1, b: 2, c: 3}"},
+		{name: "bimap precision", m: bimap, format: "%.1v", want: "{a: 1, ...and 2 more}"},
+		{name: "empty bimap", m: NewBiMap[string, int](), format: "%v", want: "{}"},
+		{name: "inverse bimap", m: bimap.Inverse(), format: "%v", want: "{1: a, 2: b, 3: c}"},
+		{name: "list multimap", m: listMultimap, format: "%+v", want: "ListMultimap[string,string] size=3 {a: [z], b: [y, x]}"},
+		{name: "list multimap verb", m: listMultimap, format: "%q", want: `{"a": ["z"], "b": ["y", "x"]}`},
+		{name: "set multimap", m: setMultimap, format: "%v", want: "{a: [1, 3]}"},
+		{name: "cache", m: c, format: "%+v", want: "Cache[string,float64] size=2 {a: 1.5, b: 2}"},
+		{name: "cache element precision", m: c, format: "%.1v", want: "{a: 1.5, ...and 1 more}"},
+		{name: "synchronized cache", m: SynchronizedCache(c), format: "%v", want: "{a: 1.5, b: 2}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.m))
+		})
+	}
+
+	assert.Equal(t, "{a: 1, b: 2, c: 3}", bimap.String())
+	assert.Equal(t, "{a: [z], b: [y, x]}", listMultimap.String())
+	assert.Equal(t, "{a: [1, 3]}", setMultimap.String())
+	assert.Equal(t, "{a: 1.5, b: 2}", c.String())
+	assert.Equal(t, CacheStats{}, c.Stats())
+	assert.Len(t, c.(*cache[string, float64]).entries, 3)
+}
+
+func TestCollection_String(t *testing.T) {
+	assert.Equal(t, "[1, 2, 3]", NewList(1, 2, 3).String())
+	assert.Equal(t, "[a, b]", NewSet("b", "a").String())
+	assert.Equal(t, "[[1], [2, 3]]", fmt.Sprint(NewList(NewFrozenList(1), NewFrozenList(2, 3))))
+	assert.Equal(t, "[1 2] [3]", fmt.Sprint(NewList(1, 2).ToSlice(), NewSet(3)))
+}
@@ -0,0 +1,179 @@
+package kol
+
+import (
+	"cmp"
+	"fmt"
+	"hash/maphash"
+	"log/slog"
+	"unique"
+
+	"golang.org/x/exp/slices"
+)
+
+// frozenNode is a node of an immutable linked list interned by unique.Make,
+// so that lists with the same elements share the same handle of the head node.
+type frozenNode[E comparable] struct {
+	value E
+	next  unique.Handle[frozenNode[E]]
+}
+
+// frozenChain interns the given elements as a chain of nodes and returns the handle of the head node.
+func frozenChain[E comparable](elements []E) unique.Handle[frozenNode[E]] {
+	var head unique.Handle[frozenNode[E]]
+	for i := len(elements) - 1; i >= 0; i-- {
+		head = unique.Make(frozenNode[E]{value: elements[i], next: head})
+	}
+	return head
+}
+
+// frozenElements returns size elements of the chain starting from the given head node.
+func frozenElements[E comparable](head unique.Handle[frozenNode[E]], size int) []E {
+	res := make([]E, 0, size)
+	for range size {
+		node := head.Value()
+		res = append(res, node.value)
+		head = node.next
+	}
+	return res
+}
+
+// FrozenList is an immutable List value.
+// FrozenLists with the same elements in the same order are equal by ==, so that they can be used as map keys.
+// The zero value is an empty list.
+type FrozenList[E comparable] struct {
+	head unique.Handle[frozenNode[E]]
+	size int
+}
+
+// NewFrozenList returns a FrozenList containing the given elements.
+func NewFrozenList[E comparable](elements ...E) FrozenList[E] {
+	return FrozenList[E]{head: frozenChain(elements), size: len(elements)}
+}
+
+// FreezeList returns a FrozenList containing the elements of the given list.
+func FreezeList[E comparable](l List[E]) FrozenList[E] {
+	return NewFrozenList(l.ToSlice()...)
+}
+
+// Hash returns the same hash as a List with the same elements.
+func (l FrozenList[E]) Hash() uint64 {
+	return hashOrdered(l.ToSlice())
+}
+
+// IsEmpty returns `true` if this list is empty.
+func (l FrozenList[E]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Size returns the number of elements.
+func (l FrozenList[E]) Size() int {
+	return l.size
+}
+
+// ToList returns a mutable List containing the elements of this list.
+func (l FrozenList[E]) ToList() List[E] {
+	return NewList(l.ToSlice()...)
+}
+
+// ToSlice returns a slice containing the elements of this list.
+func (l FrozenList[E]) ToSlice() []E {
+	return frozenElements(l.head, l.size)
+}
+
+var (
+	_ fmt.Formatter  = FrozenList[int]{}
+	_ fmt.Stringer   = FrozenList[int]{}
+	_ slog.LogValuer = FrozenList[int]{}
+)
+
+// Format implements fmt.Formatter like Collection.
+func (l FrozenList[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "FrozenList", l.ToSlice())
+}
+
+func (l FrozenList[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+// LogValue implements slog.LogValuer like Collection.
+func (l FrozenList[E]) LogValue() slog.Value {
+	return logCollection("FrozenList", l.ToSlice())
+}
+
+// FrozenSet is an immutable Set value.
+// FrozenSets with the same elements are equal by ==, so that they can be used as map keys and elements of a Set.
+// The zero value is an empty set.
+type FrozenSet[E comparable] struct {
+	head unique.Handle[frozenNode[E]]
+	size int
+}
+
+// NewFrozenSet returns a FrozenSet containing the given elements.
+func NewFrozenSet[E comparable](elements ...E) FrozenSet[E] {
+	return FreezeSet(NewSet(elements...))
+}
+
+// FreezeSet returns a FrozenSet containing the elements of the given set.
+func FreezeSet[E comparable](s Set[E]) FrozenSet[E] {
+	elements := s.ToSlice()
+	hashes := make(map[E]uint64, len(elements))
+	for _, e := range elements {
+		hashes[e] = maphash.Comparable(hashSeed, e)
+	}
+	// Elements are interned in a canonical order, so that equal sets share the same chain.
+	// Elements with colliding hashes are ordered by their string representation.
+	slices.SortFunc(elements, func(a, b E) int {
+		return cmp.Or(cmp.Compare(hashes[a], hashes[b]), cmp.Compare(fmt.Sprint(a), fmt.Sprint(b)))
+	})
+	return FrozenSet[E]{head: frozenChain(elements), size: len(elements)}
+}
+
+// Contains returns `true` if the given element is found in this set.
+func (s FrozenSet[E]) Contains(e E) bool {
+	return slices.Contains(s.ToSlice(), e)
+}
+
+// Hash returns the same hash as a Set with the same elements.
+func (s FrozenSet[E]) Hash() uint64 {
+	return hashUnordered(s.ToSlice())
+}
+
+// IsEmpty returns `true` if this set is empty.
+func (s FrozenSet[E]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Size returns the number of elements.
+func (s FrozenSet[E]) Size() int {
+	return s.size
+}
+
+// ToSet returns a mutable Set containing the elements of this set.
+func (s FrozenSet[E]) ToSet() Set[E] {
+	return NewSet(s.ToSlice()...)
+}
+
+// ToSlice returns a slice containing the elements of this set.
+func (s FrozenSet[E]) ToSlice() []E {
+	return frozenElements(s.head, s.size)
+}
+
+var (
+	_ fmt.Formatter  = FrozenSet[int]{}
+	_ fmt.Stringer   = FrozenSet[int]{}
+	_ slog.LogValuer = FrozenSet[int]{}
+)
+
+// Format implements fmt.Formatter like Collection.
+func (s FrozenSet[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "FrozenSet", sortedForDisplay(s.ToSlice()))
+}
+
+func (s FrozenSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// LogValue implements slog.LogValuer like Collection.
+func (s FrozenSet[E]) LogValue() slog.Value {
+	return logCollection("FrozenSet", sortedForDisplay(s.ToSlice()))
+}
@@ -0,0 +1,53 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrozenList(t *testing.T) {
+	a := NewFrozenList(1, 2, 3)
+	b := FreezeList(NewList(1, 2, 3))
+	assert.True(t, a == b)
+	assert.False(t, a == NewFrozenList(3, 2, 1))
+	assert.False(t, a == NewFrozenList(1, 2))
+	assert.True(t, FrozenList[int]{} == NewFrozenList[int]())
+
+	assert.Equal(t, []int{1, 2, 3}, a.ToSlice())
+	assert.Equal(t, 3, a.Size())
+	assert.False(t, a.IsEmpty())
+	assert.Equal(t, NewList(1, 2, 3).Hash(), a.Hash())
+	assert.True(t, a.ToList().Equals(NewList(1, 2, 3)))
+	assert.Equal(t, "[1, 2, 3]", a.String())
+}
+
+func TestFrozenSet(t *testing.T) {
+	a := NewFrozenSet("read", "write", "admin")
+	b := FreezeSet(NewSet("admin", "read", "write", "read"))
+	assert.True(t, a == b)
+	assert.False(t, a == NewFrozenSet("read", "write"))
+	assert.True(t, FrozenSet[string]{} == NewFrozenSet[string]())
+
+	assert.ElementsMatch(t, []string{"read", "write", "admin"}, a.ToSlice())
+	assert.Equal(t, 3, a.Size())
+	assert.True(t, a.Contains("admin"))
+	assert.False(t, a.Contains("owner"))
+	assert.Equal(t, NewSet("read", "write", "admin").Hash(), a.Hash())
+	assert.True(t, a.ToSet().Equals(NewSet("admin", "read", "write")))
+}
+
+func TestFrozenSet_SetOfSets(t *testing.T) {
+	roles := NewSet(
+		NewFrozenSet("read"),
+		NewFrozenSet("read", "write"),
+		NewFrozenSet("write", "read"),
+	)
+	assert.Equal(t, 2, roles.Size())
+	assert.True(t, roles.Contains(NewFrozenSet("write", "read")))
+
+	counts := map[FrozenList[int]]int{}
+	counts[NewFrozenList(1, 2)]++
+	counts[FreezeList(NewList(1, 2))]++
+	assert.Equal(t, map[FrozenList[int]]int{NewFrozenList(1, 2): 2}, counts)
+}
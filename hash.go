@@ -0,0 +1,25 @@
+package kol
+
+import "hash/maphash"
+
+// hashSeed is shared by all hashes of collections, so that equal collections have equal hashes within a process.
+var hashSeed = maphash.MakeSeed()
+
+// hashOrdered returns a hash of the given elements depending on their order.
+func hashOrdered[E comparable](elements []E) uint64 {
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	for _, e := range elements {
+		maphash.WriteComparable(&h, e)
+	}
+	return h.Sum64()
+}
+
+// hashUnordered returns a hash of the given distinct elements regardless of their order.
+func hashUnordered[E comparable](elements []E) uint64 {
+	var sum uint64
+	for _, e := range elements {
+		sum += maphash.Comparable(hashSeed, e)
+	}
+	return sum
+}
@@ -5,8 +5,16 @@ type Iterable[E comparable] interface {
 	All(predicate func(element E) bool) bool
 	// Any returns `true` if collection has at least one element matched the given predicate.
 	Any(predicate func(element E) bool) bool
+	// AsSequence returns a sequence that lazily iterates over this collection.
+	// If the collection is modified while the sequence is evaluated,
+	// the evaluation stops and the sequence reports ErrConcurrentModification.
+	AsSequence() Sequence[E]
 	// Contains returns `true` if the given element is found in the collection.
 	Contains(element E) bool
+	// ContainsAll returns `true` if all the given elements are found in the collection.
+	ContainsAll(elements ...E) bool
+	// ContainsAny returns `true` if at least one of the given elements is found in the collection.
+	ContainsAny(elements ...E) bool
 	// Count returns the number of elements that matches the given predicate.
 	Count(predicate func(element E) bool) int
 	// Distinct returns a collection containing only distinct elements.
@@ -17,10 +25,20 @@ type Iterable[E comparable] interface {
 	// If there is no such element, it returns `false` as a second return value.
 	Find(predicate func(element E) bool) (E, bool)
 	// ForEach performs the given action on each element.
+	// It panics with ErrConcurrentModification if the action modifies this collection.
+	// Use ForEachErr to get the error instead.
 	ForEach(action func(element E))
 	// Intersect returns a set containing all elements that are contained
 	// by both this collection and the specified collection.
 	Intersect(other Iterable[E]) Set[E]
+	// IsDisjointWith returns `true` if this collection and the specified collection have no element in common.
+	IsDisjointWith(other Iterable[E]) bool
+	// IsSubsetOf returns `true` if all elements of this collection are contained by the specified collection.
+	IsSubsetOf(other Iterable[E]) bool
+	// IsSupersetOf returns `true` if all elements of the specified collection are contained by this collection.
+	IsSupersetOf(other Iterable[E]) bool
+	// Iterator returns an iterator over the elements of this collection.
+	Iterator() Iterator[E]
 	// Map returns a collection containing the result of applying the given transform function to each element.
 	Map(transform func(element E) E) Collection[E]
 	// Minus returns a collection containing all elements of the original collection except given elements.
@@ -35,6 +53,9 @@ type Iterable[E comparable] interface {
 	// Subtract returns a set containing all elements that are contained by this collection
 	// and not contained by the specified collection.
 	Subtract(other Iterable[E]) Set[E]
+	// SymmetricDifference returns a set containing all elements that are contained
+	// by either this collection or the specified collection, but not by both.
+	SymmetricDifference(other Iterable[E]) Set[E]
 	// ToList converts this collection into List.
 	ToList() List[E]
 	// ToSet converts this collection into Set.
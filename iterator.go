@@ -2,9 +2,47 @@ package kol
 
 import "fmt"
 
+// Iterator iterates over elements of a collection.
 type Iterator[E comparable] interface {
+	// HasNext returns `true` if the iteration has more elements.
 	HasNext() bool
+	// Next returns the next element in the iteration.
+	// If the iteration has no more elements or has failed, it returns `false` as a second return value.
 	Next() (E, bool)
+	// Err returns the error that stopped the iteration, e.g. ErrConcurrentModification, or nil.
+	Err() error
+}
+
+// MutableIterator is an Iterator that supports removing elements from the underlying collection.
+type MutableIterator[E comparable] interface {
+	Iterator[E]
+
+	// Remove removes the last element returned by this iterator from the underlying collection.
+	// It returns `false` if there is no element to remove,
+	// i.e. no element has been returned yet or it has already been removed.
+	Remove() bool
+}
+
+// ListIterator is a MutableIterator over a List that can traverse the list in either direction
+// and modify the list during iteration.
+type ListIterator[E comparable] interface {
+	MutableIterator[E]
+
+	// HasPrevious returns `true` if the iteration has elements preceding the current position.
+	HasPrevious() bool
+	// Previous returns the previous element in the iteration and moves the cursor backwards.
+	// If there is no previous element, it returns `false` as a second return value.
+	Previous() (E, bool)
+	// NextIndex returns the index of the element that would be returned by a subsequent call to Next.
+	NextIndex() int
+	// PreviousIndex returns the index of the element that would be returned by a subsequent call to Previous.
+	PreviousIndex() int
+	// Set replaces the last element returned by Next or Previous with the given element.
+	// It returns `false` if there is no such element,
+	// i.e. neither Next nor Previous has been called yet, or Remove or Add has been called after them.
+	Set(element E) bool
+	// Add inserts the given element immediately before the element that would be returned by Next.
+	Add(element E)
 }
 
 type iterator[E comparable] struct {
@@ -34,6 +72,176 @@ func (i *iterator[E]) Next() (E, bool) {
 	return zero, false
 }
 
+func (i *iterator[E]) Err() error {
+	return nil
+}
+
 func (i *iterator[E]) String() string {
 	return fmt.Sprintf("cursor: %d, elements: %v", i.cursor, i.elements)
 }
+
+// indexedList is a List with random access modifications, which listIterator iterates over.
+type indexedList[E comparable] interface {
+	List[E]
+
+	modification() *modCount
+	set(idx int, e E)
+	insert(idx int, e E)
+	removeAt(idx int)
+}
+
+type listIterator[E comparable] struct {
+	list         indexedList[E]
+	cursor       int
+	lastReturned int
+	expectedMod  int
+	err          error
+}
+
+var _ ListIterator[int] = (*listIterator[int])(nil)
+
+func newListIterator[E comparable](l indexedList[E]) *listIterator[E] {
+	return &listIterator[E]{list: l, cursor: 0, lastReturned: -1, expectedMod: l.modification().count()}
+}
+
+// check records and returns ErrConcurrentModification if the list has been modified outside this iterator.
+func (i *listIterator[E]) check() error {
+	if i.err == nil {
+		i.err = i.list.modification().check(i.expectedMod)
+	}
+	return i.err
+}
+
+func (i *listIterator[E]) HasNext() bool {
+	return i.cursor < i.list.Size()
+}
+
+func (i *listIterator[E]) Next() (E, bool) {
+	if i.check() != nil || i.cursor >= i.list.Size() {
+		var zero E
+		return zero, false
+	}
+	e, _ := i.list.ElementAt(i.cursor)
+	i.lastReturned = i.cursor
+	i.cursor++
+	return e, true
+}
+
+func (i *listIterator[E]) Err() error {
+	return i.err
+}
+
+func (i *listIterator[E]) HasPrevious() bool {
+	return i.cursor > 0
+}
+
+func (i *listIterator[E]) Previous() (E, bool) {
+	if i.check() != nil || i.cursor <= 0 {
+		var zero E
+		return zero, false
+	}
+	i.cursor--
+	i.lastReturned = i.cursor
+	e, _ := i.list.ElementAt(i.cursor)
+	return e, true
+}
+
+func (i *listIterator[E]) NextIndex() int {
+	return i.cursor
+}
+
+func (i *listIterator[E]) PreviousIndex() int {
+	return i.cursor - 1
+}
+
+func (i *listIterator[E]) Remove() bool {
+	if i.check() != nil || i.lastReturned < 0 {
+		return false
+	}
+	i.list.removeAt(i.lastReturned)
+	if i.lastReturned < i.cursor {
+		i.cursor--
+	}
+	i.lastReturned = -1
+	i.expectedMod = i.list.modification().count()
+	return true
+}
+
+func (i *listIterator[E]) Set(e E) bool {
+	if i.check() != nil || i.lastReturned < 0 {
+		return false
+	}
+	i.list.set(i.lastReturned, e)
+	return true
+}
+
+func (i *listIterator[E]) Add(e E) {
+	if i.check() != nil {
+		return
+	}
+	size := i.list.Size()
+	i.list.insert(i.cursor, e)
+	// The list may evict or reject an element instead of growing, e.g. a full RingBuffer.
+	i.cursor += i.list.Size() - size
+	i.lastReturned = -1
+	i.expectedMod = i.list.modification().count()
+}
+
+func (i *listIterator[E]) String() string {
+	return fmt.Sprintf("cursor: %d, elements: %v", i.cursor, i.list.ToSlice())
+}
+
+// snapshotIterator iterates over a copy of elements taken when the iterator is created,
+// and removes elements from the underlying collection by the given remove function.
+// If mod is not nil, it stops with ErrConcurrentModification once the collection is modified outside the iterator.
+type snapshotIterator[E comparable] struct {
+	iterator[E]
+	remove      func(e E)
+	removable   bool
+	mod         *modCount
+	expectedMod int
+	err         error
+}
+
+var _ MutableIterator[int] = (*snapshotIterator[int])(nil)
+
+func newSnapshotIterator[E comparable](elements []E, remove func(e E), mod *modCount) *snapshotIterator[E] {
+	return &snapshotIterator[E]{
+		iterator:    iterator[E]{elements: elements, cursor: 0},
+		remove:      remove,
+		mod:         mod,
+		expectedMod: mod.count(),
+	}
+}
+
+func (i *snapshotIterator[E]) check() error {
+	if i.err == nil {
+		i.err = i.mod.check(i.expectedMod)
+	}
+	return i.err
+}
+
+func (i *snapshotIterator[E]) Next() (E, bool) {
+	if i.check() != nil {
+		i.removable = false
+		var zero E
+		return zero, false
+	}
+	e, ok := i.iterator.Next()
+	i.removable = ok
+	return e, ok
+}
+
+func (i *snapshotIterator[E]) Err() error {
+	return i.err
+}
+
+func (i *snapshotIterator[E]) Remove() bool {
+	if i.check() != nil || !i.removable {
+		return false
+	}
+	i.remove(i.elements[i.cursor-1])
+	i.removable = false
+	i.expectedMod = i.mod.count()
+	return true
+}
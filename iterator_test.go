@@ -87,3 +87,158 @@ func Test_iterator_Next(t *testing.T) {
 		})
 	}
 }
+
+func TestList_Iterator(t *testing.T) {
+	l := NewList(1, 2, 3)
+	iter := l.Iterator()
+	got := make([]int, 0)
+	for iter.HasNext() {
+		e, ok := iter.Next()
+		assert.True(t, ok)
+		got = append(got, e)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	e, ok := iter.Next()
+	assert.Equal(t, 0, e)
+	assert.False(t, ok)
+}
+
+func TestList_MutableIterator(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   List[int]
+		remove func(n int, e int) bool
+		want   []int
+	}{
+		{
+			name:   "remove some elements",
+			list:   NewList(1, 2, 3, 4, 5),
+			remove: func(_ int, e int) bool { return e%2 == 0 },
+			want:   []int{1, 3, 5},
+		},
+		{
+			name:   "remove all elements",
+			list:   NewList(1, 2, 3),
+			remove: func(_ int, _ int) bool { return true },
+			want:   []int{},
+		},
+		{
+			name:   "remove duplicated element at the iterated position",
+			list:   NewList(1, 2, 1),
+			remove: func(n int, _ int) bool { return n == 2 },
+			want:   []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iter := tt.list.MutableIterator()
+			for n := 0; iter.HasNext(); n++ {
+				e, _ := iter.Next()
+				if tt.remove(n, e) {
+					assert.True(t, iter.Remove())
+				}
+			}
+			assert.Equal(t, tt.want, tt.list.ToSlice())
+		})
+	}
+
+	t.Run("remove without next", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		iter := l.MutableIterator()
+		assert.False(t, iter.Remove())
+
+		iter.Next()
+		assert.True(t, iter.Remove())
+		assert.False(t, iter.Remove())
+		assert.Equal(t, []int{2, 3}, l.ToSlice())
+	})
+}
+
+func TestList_ListIterator(t *testing.T) {
+	t.Run("traverse in both directions", func(t *testing.T) {
+		iter := NewList(1, 2, 3).ListIterator()
+		assert.False(t, iter.HasPrevious())
+		assert.Equal(t, 0, iter.NextIndex())
+		assert.Equal(t, -1, iter.PreviousIndex())
+
+		for iter.HasNext() {
+			iter.Next()
+		}
+		assert.Equal(t, 3, iter.NextIndex())
+
+		got := make([]int, 0)
+		for iter.HasPrevious() {
+			e, ok := iter.Previous()
+			assert.True(t, ok)
+			got = append(got, e)
+		}
+		assert.Equal(t, []int{3, 2, 1}, got)
+
+		e, ok := iter.Previous()
+		assert.Equal(t, 0, e)
+		assert.False(t, ok)
+	})
+
+	t.Run("set replaces the last returned element", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		iter := l.ListIterator()
+		assert.False(t, iter.Set(0))
+
+		iter.Next()
+		iter.Next()
+		assert.True(t, iter.Set(20))
+		iter.Previous()
+		assert.True(t, iter.Set(200))
+		assert.Equal(t, []int{1, 200, 3}, l.ToSlice())
+	})
+
+	t.Run("add inserts before the cursor", func(t *testing.T) {
+		l := NewList(1, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		iter.Add(2)
+		assert.False(t, iter.Set(0))
+		assert.False(t, iter.Remove())
+		assert.Equal(t, 2, iter.NextIndex())
+
+		e, _ := iter.Next()
+		assert.Equal(t, 3, e)
+		iter.Add(4)
+		assert.False(t, iter.HasNext())
+		assert.Equal(t, []int{1, 2, 3, 4}, l.ToSlice())
+	})
+
+	t.Run("remove after previous", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		iter.Next()
+		iter.Previous()
+		assert.True(t, iter.Remove())
+		assert.Equal(t, 1, iter.NextIndex())
+
+		e, _ := iter.Next()
+		assert.Equal(t, 3, e)
+		assert.Equal(t, []int{1, 3}, l.ToSlice())
+	})
+}
+
+func TestSet_MutableIterator(t *testing.T) {
+	s := NewSet(1, 2, 3, 4, 5)
+	iter := s.MutableIterator()
+	assert.False(t, iter.Remove())
+
+	count := 0
+	for iter.HasNext() {
+		e, ok := iter.Next()
+		assert.True(t, ok)
+		count++
+		if e%2 == 0 {
+			assert.True(t, iter.Remove())
+			assert.False(t, iter.Remove())
+		}
+	}
+	assert.Equal(t, 5, count)
+	assert.ElementsMatch(t, []int{1, 3, 5}, s.ToSlice())
+}
@@ -0,0 +1,260 @@
+package kol
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"golang.org/x/exp/slices"
+)
+
+// JSONList is a List encoded as a JSON array, which can be a field of a struct decoded by encoding/json.
+// A field of the List interface type must be initialized by NewList before decoding,
+// but the zero value of JSONList decodes a JSON array into a new List.
+// A nil List is encoded as null, and null is decoded as a nil List.
+//
+// It also implements sql.Scanner and driver.Valuer to store the list in a SQL column as a JSON array,
+// where a nil List is stored as NULL.
+type JSONList[E comparable] struct {
+	List[E]
+}
+
+var (
+	_ json.Marshaler   = JSONList[int]{}
+	_ json.Unmarshaler = (*JSONList[int])(nil)
+)
+
+// MarshalJSON encodes the list as a JSON array, or null if it is nil.
+func (l JSONList[E]) MarshalJSON() ([]byte, error) {
+	if l.List == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(l.List)
+}
+
+// UnmarshalJSON replaces the list with a new List decoded from a JSON array, or nil if the JSON value is null.
+func (l *JSONList[E]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		l.List = nil
+		return nil
+	}
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	l.List = NewList(elements...)
+	return nil
+}
+
+var (
+	_ fmt.Formatter  = JSONList[int]{}
+	_ fmt.Stringer   = JSONList[int]{}
+	_ slog.LogValuer = JSONList[int]{}
+)
+
+// Format implements fmt.Formatter like List, and formats a nil List as an empty one.
+func (l JSONList[E]) Format(f fmt.State, verb rune) {
+	formatWrapped(f, verb, "List", l.List)
+}
+
+func (l JSONList[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+// LogValue implements slog.LogValuer like List, and logs a nil List as an empty one.
+func (l JSONList[E]) LogValue() slog.Value {
+	return logWrapped("List", l.List)
+}
+
+// JSONSet is a Set encoded as a JSON array, which can be a field of a struct decoded by encoding/json.
+// A field of the Set interface type must be initialized by NewSet before decoding,
+// but the zero value of JSONSet decodes a JSON array into a new Set.
+// A nil Set is encoded as null, and null is decoded as a nil Set.
+//
+// It also implements sql.Scanner and driver.Valuer to store the set in a SQL column as a JSON array,
+// where a nil Set is stored as NULL.
+type JSONSet[E comparable] struct {
+	Set[E]
+}
+
+var (
+	_ json.Marshaler   = JSONSet[int]{}
+	_ json.Unmarshaler = (*JSONSet[int])(nil)
+)
+
+// MarshalJSON encodes the set as a JSON array, or null if it is nil.
+func (s JSONSet[E]) MarshalJSON() ([]byte, error) {
+	if s.Set == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.Set)
+}
+
+// UnmarshalJSON replaces the set with a new Set decoded from a JSON array, or nil if the JSON value is null.
+func (s *JSONSet[E]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		s.Set = nil
+		return nil
+	}
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	s.Set = NewSet(elements...)
+	return nil
+}
+
+var (
+	_ fmt.Formatter  = JSONSet[int]{}
+	_ fmt.Stringer   = JSONSet[int]{}
+	_ slog.LogValuer = JSONSet[int]{}
+)
+
+// Format implements fmt.Formatter like Set, and formats a nil Set as an empty one.
+func (s JSONSet[E]) Format(f fmt.State, verb rune) {
+	formatWrapped(f, verb, "Set", s.Set)
+}
+
+func (s JSONSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// LogValue implements slog.LogValuer like Set, and logs a nil Set as an empty one.
+func (s JSONSet[E]) LogValue() slog.Value {
+	return logWrapped("Set", s.Set)
+}
+
+// SortedJSONSet is a JSONSet encoded in sorted order, so that the encoding of equal sets is deterministic.
+// Numbers are sorted numerically, and other elements are sorted by their JSON encoding.
+//
+// It also implements sql.Scanner and driver.Valuer like JSONSet.
+type SortedJSONSet[E comparable] struct {
+	Set[E]
+}
+
+var (
+	_ json.Marshaler   = SortedJSONSet[int]{}
+	_ json.Unmarshaler = (*SortedJSONSet[int])(nil)
+)
+
+// MarshalJSON encodes the set as a JSON array in sorted order, or null if it is nil.
+func (s SortedJSONSet[E]) MarshalJSON() ([]byte, error) {
+	if s.Set == nil {
+		return []byte("null"), nil
+	}
+	return marshalJSONSorted(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the set with a new Set decoded from a JSON array, or nil if the JSON value is null.
+func (s *SortedJSONSet[E]) UnmarshalJSON(data []byte) error {
+	return (*JSONSet[E])(s).UnmarshalJSON(data)
+}
+
+var (
+	_ fmt.Formatter  = SortedJSONSet[int]{}
+	_ fmt.Stringer   = SortedJSONSet[int]{}
+	_ slog.LogValuer = SortedJSONSet[int]{}
+)
+
+// Format implements fmt.Formatter like Set, and formats a nil Set as an empty one.
+func (s SortedJSONSet[E]) Format(f fmt.State, verb rune) {
+	formatWrapped(f, verb, "Set", s.Set)
+}
+
+func (s SortedJSONSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// LogValue implements slog.LogValuer like Set, and logs a nil Set as an empty one.
+func (s SortedJSONSet[E]) LogValue() slog.Value {
+	return logWrapped("Set", s.Set)
+}
+
+// UnmarshalList decodes the given JSON array into a List.
+func UnmarshalList[E comparable](data []byte) (List[E], error) {
+	l := NewList[E]()
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UnmarshalSet decodes the given JSON array into a Set.
+func UnmarshalSet[E comparable](data []byte) (Set[E], error) {
+	s := NewSet[E]()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// marshalJSONElements encodes the given elements as a JSON array.
+func marshalJSONElements[E comparable](elements []E) ([]byte, error) {
+	if elements == nil {
+		elements = make([]E, 0)
+	}
+	return json.Marshal(elements)
+}
+
+// marshalJSONSorted encodes the given elements as a JSON array sorted by compareJSON.
+func marshalJSONSorted[E comparable](elements []E) ([]byte, error) {
+	encoded := make([][]byte, len(elements))
+	for i, e := range elements {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = b
+	}
+	slices.SortFunc(encoded, compareJSON)
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, b := range encoded {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// compareJSON compares encoded JSON values numerically if both are numbers, or by their bytes otherwise.
+func compareJSON(a, b []byte) int {
+	fa, errA := strconv.ParseFloat(string(a), 64)
+	fb, errB := strconv.ParseFloat(string(b), 64)
+	if errA == nil && errB == nil {
+		if c := cmp.Compare(fa, fb); c != 0 {
+			return c
+		}
+	}
+	return bytes.Compare(a, b)
+}
+
+// isJSONNull reports whether the given JSON value is null.
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// unmarshalJSONElements decodes the given JSON array. JSON null is decoded as no elements.
+func unmarshalJSONElements[E comparable](data []byte) ([]E, error) {
+	var elements []E
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// unmarshalJSONInto replaces the elements of the given collection with the decoded JSON array.
+func unmarshalJSONInto[E comparable](c Collection[E], data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	c.Clear()
+	c.Add(elements...)
+	return nil
+}
@@ -0,0 +1,246 @@
+package kol
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+		want       string
+	}{
+		{name: "list", collection: NewList(3, 1, 2), want: `[3,1,2]`},
+		{name: "empty list", collection: NewList[int](), want: `[]`},
+		{name: "set", collection: NewSet(10, 9, 1), want: `[1,9,10]`},
+		{name: "deque", collection: NewDeque(1, 2, 3), want: `[1,2,3]`},
+		{name: "ring buffer", collection: NewRingBuffer[int](2, OverflowOverwrite), want: `[]`},
+		{name: "multiset", collection: NewMultiset(2, 1, 2), want: `[1,2,2]`},
+		{name: "concurrent list", collection: NewConcurrentList(2, 1), want: `[2,1]`},
+		{name: "concurrent set", collection: NewConcurrentSet(2, 1), want: `[1,2]`},
+		{name: "synchronized list", collection: SynchronizedList(NewList(2, 1)), want: `[2,1]`},
+		{name: "synchronized set", collection: SynchronizedSet(NewSet(2, 1)), want: `[1,2]`},
+		{name: "expiring set", collection: NewExpiringSet(time.Hour, nil, 2, 1), want: `[1,2]`},
+		{name: "priority queue", collection: NewPriorityQueue(1), want: `[1]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.collection)
+			assert.NoError(t, err)
+			if _, ok := tt.collection.(List[int]); ok {
+				assert.Equal(t, tt.want, string(got))
+				return
+			}
+			var want, elements []int
+			assert.NoError(t, json.Unmarshal([]byte(tt.want), &want))
+			assert.NoError(t, json.Unmarshal(got, &elements))
+			assert.ElementsMatch(t, want, elements)
+		})
+	}
+}
+
+func TestSortedJSONSet(t *testing.T) {
+	got, err := json.Marshal(SortedJSONSet[int]{NewSet(10, 9, 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,9,10]`, string(got))
+
+	type tag struct {
+		Name string `json:"name"`
+	}
+	got, err = json.Marshal(SortedJSONSet[tag]{NewSet(tag{Name: "b"}, tag{Name: "a"})})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"name":"a"},{"name":"b"}]`, string(got))
+
+	type dto struct {
+		Roles SortedJSONSet[string] `json:"roles"`
+	}
+	var decoded dto
+	assert.NoError(t, json.Unmarshal([]byte(`{"roles":["write","read","write"]}`), &decoded))
+	got, err = json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"roles":["read","write"]}`, string(got))
+
+	got, err = json.Marshal(dto{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"roles":null}`, string(got))
+}
+
+func TestJSONList_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		wrapper any
+		format  string
+		want    string
+	}{
+		{name: "list", wrapper: JSONList[int]{NewList(2, 1)}, format: "%v", want: "[2, 1]"},
+		{name: "zero list", wrapper: JSONList[int]{}, format: "%v", want: "[]"},
+		{name: "zero list plus", wrapper: JSONList[int]{}, format: "%+v", want: "List[int] size=0 []"},
+		{name: "set", wrapper: JSONSet[int]{NewSet(2, 1)}, format: "%v", want: "[1, 2]"},
+		{name: "zero set", wrapper: JSONSet[string]{}, format: "%+v", want: "Set[string] size=0 []"},
+		{name: "sorted set", wrapper: SortedJSONSet[int]{NewSet(2, 1)}, format: "%.1v", want: "[1, ...and 1 more]"},
+		{name: "zero sorted set", wrapper: SortedJSONSet[int]{}, format: "%s", want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.wrapper))
+		})
+	}
+
+	assert.Equal(t, "[]", JSONList[int]{}.String())
+	assert.Equal(t, "[]", JSONSet[int]{}.String())
+	assert.Equal(t, "[]", SortedJSONSet[int]{}.String())
+	assert.Equal(t, "[type=List[int] size=0 sample=[]]", JSONList[int]{}.LogValue().String())
+	assert.Equal(t, "[type=Set[int] size=0 sample=[]]", SortedJSONSet[int]{}.LogValue().String())
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+		data       string
+		want       []int
+	}{
+		{name: "list", collection: NewList(9), data: `[3,1,3]`, want: []int{3, 1, 3}},
+		{name: "null list", collection: NewList(9), data: `null`, want: []int{}},
+		{name: "set", collection: NewSet(9), data: `[3,1,3]`, want: []int{1, 3}},
+		{name: "deque", collection: NewDeque(9), data: `[3,1]`, want: []int{3, 1}},
+		{name: "ring buffer", collection: NewRingBuffer[int](2, OverflowOverwrite), data: `[1,2,3]`, want: []int{2, 3}},
+		{name: "multiset", collection: NewMultiset(9), data: `[3,3]`, want: []int{3, 3}},
+		{name: "concurrent list", collection: NewConcurrentList(9), data: `[2,1]`, want: []int{2, 1}},
+		{name: "concurrent set", collection: NewConcurrentSet(9), data: `[2,1]`, want: []int{1, 2}},
+		{name: "synchronized list", collection: SynchronizedList(NewList(9)), data: `[2,1]`, want: []int{2, 1}},
+		{name: "blocking queue", collection: NewBlockingQueue[int](2), data: `[2,1]`, want: []int{2, 1}},
+		{name: "expiring set", collection: NewExpiringSet(time.Hour, nil, 9), data: `[1]`, want: []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, json.Unmarshal([]byte(tt.data), tt.collection))
+			if _, ok := tt.collection.(List[int]); ok {
+				assert.Equal(t, tt.want, tt.collection.ToSlice())
+			} else {
+				assert.ElementsMatch(t, tt.want, tt.collection.ToSlice())
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON_Error(t *testing.T) {
+	assert.Error(t, json.Unmarshal([]byte(`{}`), NewList[int]()))
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), NewSet[int]()))
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[1,2,3]`), NewBlockingQueue[int](2)), ErrQueueFull)
+
+	l := NewList(1)
+	assert.Error(t, json.Unmarshal([]byte(`[1,"a"]`), l))
+	assert.Equal(t, []int{1}, l.ToSlice())
+}
+
+func TestBiMap_JSON(t *testing.T) {
+	b, _ := NewBiMapFromPairs(NewList(NewPair("b", 2), NewPair("a", 1)))
+	got, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":2}`, string(got))
+
+	decoded := NewBiMap[string, int]()
+	inverse := decoded.Inverse()
+	assert.NoError(t, decoded.Put("z", 9))
+	assert.NoError(t, json.Unmarshal([]byte(`{"x":1,"y":2}`), decoded))
+	assert.ElementsMatch(t, []Pair[string, int]{NewPair("x", 1), NewPair("y", 2)}, decoded.Entries().ToSlice())
+	k, ok := inverse.GetByKey(2)
+	assert.True(t, ok)
+	assert.Equal(t, "y", k)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"p":1,"q":1}`), decoded), ErrDuplicateValue)
+	assert.Equal(t, 2, decoded.Size())
+	assert.Error(t, json.Unmarshal([]byte(`["x"]`), decoded))
+}
+
+func TestMultimap_JSON(t *testing.T) {
+	lm := NewListMultimap[string, int]()
+	lm.PutAll("b", 3, 1, 3)
+	lm.Put("a", 2)
+	got, err := json.Marshal(lm)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[2],"b":[3,1,3]}`, string(got))
+
+	decodedList := NewListMultimap[string, int]()
+	decodedList.Put("z", 9)
+	assert.NoError(t, json.Unmarshal(got, decodedList))
+	assert.Equal(t, 4, decodedList.Size())
+	assert.Equal(t, []int{3, 1, 3}, decodedList.Get("b").ToSlice())
+	assert.False(t, decodedList.ContainsKey("z"))
+
+	sm := NewSetMultimap[int, string]()
+	sm.PutAll(1, "x")
+	got, err = json.Marshal(sm)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1":["x"]}`, string(got))
+
+	decodedSet := NewSetMultimap[int, string]()
+	assert.NoError(t, json.Unmarshal([]byte(`{"1":["x","y","x"],"2":[]}`), decodedSet))
+	assert.Equal(t, 2, decodedSet.Size())
+	assert.ElementsMatch(t, []string{"x", "y"}, decodedSet.Get(1).ToSlice())
+	assert.False(t, decodedSet.ContainsKey(2))
+	assert.Error(t, json.Unmarshal([]byte(`{"a":["x"]}`), decodedSet))
+}
+
+func TestUnmarshalList(t *testing.T) {
+	l, err := UnmarshalList[string]([]byte(`["a","b"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, l.ToSlice())
+
+	s, err := UnmarshalSet[string]([]byte(`["a","b","a"]`))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, s.ToSlice())
+
+	_, err = UnmarshalList[string]([]byte(`[1]`))
+	assert.Error(t, err)
+}
+
+func TestJSON_DTO(t *testing.T) {
+	type dto struct {
+		Tags  List[string] `json:"tags"`
+		Roles Set[string]  `json:"roles"`
+	}
+	data, err := json.Marshal(dto{Tags: NewList("b", "a"), Roles: NewSet("read")})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["b","a"],"roles":["read"]}`, string(data))
+
+	decoded := dto{Tags: NewList[string](), Roles: NewSet[string]()}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.Tags.Equals(NewList("b", "a")))
+	assert.True(t, decoded.Roles.Equals(NewSet("read")))
+}
+
+func TestJSONList_DTO(t *testing.T) {
+	type dto struct {
+		Tags    JSONList[string] `json:"tags"`
+		Roles   JSONSet[string]  `json:"roles"`
+		Ports   JSONList[int]    `json:"ports"`
+		Missing JSONSet[int]     `json:"missing"`
+	}
+
+	var decoded dto
+	assert.NoError(t, json.Unmarshal([]byte(`{"tags":["b","a","b"],"roles":["read","write","read"],"ports":null}`), &decoded))
+	assert.Equal(t, []string{"b", "a", "b"}, decoded.Tags.ToSlice())
+	assert.ElementsMatch(t, []string{"read", "write"}, decoded.Roles.ToSlice())
+	assert.Nil(t, decoded.Ports.List)
+	assert.Nil(t, decoded.Missing.Set)
+
+	decoded.Roles = JSONSet[string]{NewSet("read")}
+	data, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["b","a","b"],"roles":["read"],"ports":null,"missing":null}`, string(data))
+
+	var again dto
+	assert.NoError(t, json.Unmarshal(data, &again))
+	assert.True(t, again.Tags.Equals(decoded.Tags.List))
+	assert.True(t, again.Roles.Equals(decoded.Roles.Set))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"tags":[1]}`), &again))
+	assert.Error(t, json.Unmarshal([]byte(`{"roles":{}}`), &again))
+}
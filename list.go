@@ -1,10 +1,27 @@
 package kol
 
 import (
+	"cmp"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
 	"golang.org/x/exp/rand"
 	"golang.org/x/exp/slices"
 )
 
+// Semantics decides how the ordered set operations of List treat duplicate elements.
+type Semantics int
+
+const (
+	// SetSemantics keeps only the first occurrence of each element, like the operations of Set.
+	SetSemantics Semantics = iota
+	// BagSemantics respects the number of occurrences of each element, like the operations of Multiset.
+	BagSemantics
+)
+
 // List is an ordered collection of elements.
 type List[E comparable] interface {
 	Collection[E]
@@ -19,19 +36,36 @@ type List[E comparable] interface {
 	// ElementAtOrElse returns an element at the given index or the result calling of the defaultValue function
 	// if the index is out of range of this collection.
 	ElementAtOrElse(index int, defaultValue func() E) E
+	// Equals returns `true` if the specified collection is a List containing the same elements in the same order.
+	Equals(other Iterable[E]) bool
 	// FilterIndexed returns a list containing only elements matching the given predicate.
 	FilterIndexed(predicate func(idx int, element E) bool) Collection[E]
 	// FindLast returns the last element matching the given predicate.
 	// If there is no such element, it returns `false` as a second return value.
 	FindLast(predicate func(element E) bool) (E, bool)
 	// ForEachIndexed performs the given action on each element.
+	// It panics with ErrConcurrentModification if the action modifies this list.
 	ForEachIndexed(action func(index int, element E))
+	// Hash returns a hash of the elements, which is equal for equal lists.
+	// It is stable during the lifetime of the process, but differs between processes.
+	Hash() uint64
 	// IndexOf returns an index of the first element matching the given element, or -1 if not preset.
 	IndexOf(element E) int
 	// IndexOfFirst returns an index of the first element matching the given predicate, or -1 if not present.
 	IndexOfFirst(predicate func(element E) bool) int
 	// IndexOfLast returns an index of the last element matching the given predicate, or -1 if not present.
 	IndexOfLast(predicate func(element E) bool) int
+	// IntersectOrdered returns a list containing elements of this list that are also contained by the specified collection,
+	// keeping the order of this list.
+	// With BagSemantics, each element occurs as many times as the minimum of its occurrences in both collections.
+	IntersectOrdered(other Iterable[E], semantics Semantics) List[E]
+	// JoinToString returns a string of the elements formatted by fmt.Sprint, separated by separator
+	// and enclosed by prefix and postfix. If limit is not negative, only the first limit elements are rendered,
+	// followed by truncated if there are more elements.
+	JoinToString(separator, prefix, postfix string, limit int, truncated string) string
+	// ListIterator returns a bidirectional iterator over the elements of this list
+	// that supports modifying the list during iteration.
+	ListIterator() ListIterator[E]
 	// MapIndexed returns a list containing the results of applying the given transform function
 	// to each element and its index.
 	MapIndexed(transform func(idx int, element E) E) Collection[E]
@@ -43,14 +77,23 @@ type List[E comparable] interface {
 	Reversed() List[E]
 	// Shuffled returns a list with elements in shuffled order.
 	Shuffled() List[E]
+	// SubtractOrdered returns a list containing elements of this list that are not contained by the specified collection,
+	// keeping the order of this list.
+	// With BagSemantics, each occurrence in the specified collection cancels one occurrence in this list.
+	SubtractOrdered(other Iterable[E], semantics Semantics) List[E]
 	// Take returns a list containing first n elements.
 	Take(n uint) Collection[E]
 	// TakeWhile returns a list containing first elements satisfying the given predicate.
 	TakeWhile(predicate func(element E) bool) Collection[E]
+	// UnionOrdered returns a list containing elements of this list followed by elements of the specified collection
+	// that are not contained by this list, keeping their first-occurrence order.
+	// With BagSemantics, each element occurs as many times as the maximum of its occurrences in both collections.
+	UnionOrdered(other Iterable[E], semantics Semantics) List[E]
 }
 
 type list[E comparable] struct {
 	elements []E
+	mod      *modCount
 }
 
 func NewList[E comparable](elements ...E) List[E] {
@@ -68,24 +111,54 @@ func (l *list[E]) clone() List[E] {
 	}
 }
 
+func (l *list[E]) modification() *modCount {
+	if l.mod == nil {
+		l.mod = &modCount{}
+	}
+	return l.mod
+}
+
+func (l *list[E]) set(idx int, e E) {
+	l.elements[idx] = e
+}
+
+func (l *list[E]) insert(idx int, e E) {
+	l.elements = slices.Insert(l.elements, idx, e)
+	l.mod.increment()
+}
+
+func (l *list[E]) removeAt(idx int) {
+	l.elements = slices.Delete(l.elements, idx, idx+1)
+	l.mod.increment()
+}
+
 var _ List[int] = (*list[int])(nil)
 
 func (l *list[E]) Add(elements ...E) {
+	if len(elements) == 0 {
+		return
+	}
 	l.elements = append(l.elements, elements...)
+	l.mod.increment()
 }
 
 func (l *list[E]) Clear() {
 	l.elements = []E{}
+	l.mod.increment()
 }
 
 func (l *list[E]) IsEmpty() bool {
 	return l.Size() == 0
 }
 
+func (l *list[E]) MutableIterator() MutableIterator[E] {
+	return newListIterator(l)
+}
+
 func (l *list[E]) Remove(targets ...E) {
 	for _, t := range targets {
 		if idx := slices.Index(l.elements, t); idx >= 0 {
-			l.elements = slices.Delete(l.elements, idx, idx+1)
+			l.removeAt(idx)
 		}
 	}
 }
@@ -97,6 +170,9 @@ func (l *list[E]) Retain(targets ...E) {
 			retained = append(retained, e)
 		}
 	}
+	if len(retained) != len(l.elements) {
+		l.mod.increment()
+	}
 	l.elements = retained
 }
 
@@ -127,10 +203,22 @@ func (l *list[E]) Any(p func(e E) bool) bool {
 	return false
 }
 
+func (l *list[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](l.Iterator()))
+}
+
 func (l *list[E]) Contains(e E) bool {
 	return slices.Contains(l.elements, e)
 }
 
+func (l *list[E]) ContainsAll(elements ...E) bool {
+	return l.ToSet().ContainsAll(elements...)
+}
+
+func (l *list[E]) ContainsAny(elements ...E) bool {
+	return l.ToSet().ContainsAny(elements...)
+}
+
 func (l *list[E]) Count(p func(e E) bool) int {
 	count := 0
 	for _, e := range l.elements {
@@ -190,6 +278,11 @@ func (l *list[E]) ElementAtOrElse(idx int, f func() E) E {
 	return e
 }
 
+func (l *list[E]) Equals(other Iterable[E]) bool {
+	o, ok := other.(List[E])
+	return ok && o.Size() == len(l.elements) && slices.Equal(l.elements, o.ToSlice())
+}
+
 func (l *list[E]) Filter(p func(e E) bool) Collection[E] {
 	return l.FilterIndexed(func(_ int, e E) bool {
 		return p(e)
@@ -231,11 +324,20 @@ func (l *list[E]) ForEach(a func(e E)) {
 }
 
 func (l *list[E]) ForEachIndexed(a func(idx int, e E)) {
+	mod := l.modification()
+	expected := mod.count()
 	for i, e := range l.elements {
 		a(i, e)
+		if err := mod.check(expected); err != nil {
+			panic(err)
+		}
 	}
 }
 
+func (l *list[E]) Hash() uint64 {
+	return hashOrdered(l.elements)
+}
+
 func (l *list[E]) IndexOf(e E) int {
 	return slices.Index(l.elements, e)
 }
@@ -262,6 +364,65 @@ func (l *list[E]) Intersect(other Iterable[E]) Set[E] {
 	return l.ToSet().Intersect(other)
 }
 
+func (l *list[E]) IsDisjointWith(other Iterable[E]) bool {
+	return l.ToSet().IsDisjointWith(other)
+}
+
+func (l *list[E]) IsSubsetOf(other Iterable[E]) bool {
+	return l.ToSet().IsSubsetOf(other)
+}
+
+func (l *list[E]) IsSupersetOf(other Iterable[E]) bool {
+	return l.ToSet().IsSupersetOf(other)
+}
+
+// countElements returns the number of occurrences of each element.
+func countElements[E comparable](elements []E) map[E]int {
+	counts := make(map[E]int)
+	for _, e := range elements {
+		counts[e]++
+	}
+	return counts
+}
+
+func (l *list[E]) IntersectOrdered(other Iterable[E], semantics Semantics) List[E] {
+	res := make([]E, 0)
+	if semantics == BagSemantics {
+		counts := countElements(other.ToSlice())
+		for _, e := range l.elements {
+			if counts[e] > 0 {
+				res = append(res, e)
+				counts[e]--
+			}
+		}
+		return NewList(res...)
+	}
+	o := distinctOf(other)
+	seen := make(map[E]struct{})
+	for _, e := range l.elements {
+		if _, ok := o[e]; !ok {
+			continue
+		}
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			res = append(res, e)
+		}
+	}
+	return NewList(res...)
+}
+
+func (l *list[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return l.AsSequence().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (l *list[E]) Iterator() Iterator[E] {
+	return newListIterator(l)
+}
+
+func (l *list[E]) ListIterator() ListIterator[E] {
+	return newListIterator(l)
+}
+
 func (l *list[E]) Map(t func(e E) E) Collection[E] {
 	return l.MapIndexed(func(_ int, e E) E {
 		return t(e)
@@ -344,7 +505,38 @@ func (l *list[E]) Single(p func(e E) bool) (E, bool) {
 }
 
 func (l *list[E]) Subtract(other Iterable[E]) Set[E] {
-	return l.ToSet().Intersect(other)
+	return l.ToSet().Subtract(other)
+}
+
+func (l *list[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return l.ToSet().SymmetricDifference(other)
+}
+
+func (l *list[E]) SubtractOrdered(other Iterable[E], semantics Semantics) List[E] {
+	res := make([]E, 0)
+	if semantics == BagSemantics {
+		counts := countElements(other.ToSlice())
+		for _, e := range l.elements {
+			if counts[e] > 0 {
+				counts[e]--
+			} else {
+				res = append(res, e)
+			}
+		}
+		return NewList(res...)
+	}
+	o := distinctOf(other)
+	seen := make(map[E]struct{})
+	for _, e := range l.elements {
+		if _, ok := o[e]; ok {
+			continue
+		}
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			res = append(res, e)
+		}
+	}
+	return NewList(res...)
 }
 
 func (l *list[E]) Take(n uint) Collection[E] {
@@ -376,7 +568,37 @@ func (l *list[E]) ToSlice() []E {
 }
 
 func (l *list[E]) Union(other Iterable[E]) Set[E] {
-	return l.ToSet().Plus(other.ToSlice()...)
+	return l.ToSet().Union(other)
+}
+
+func (l *list[E]) UnionOrdered(other Iterable[E], semantics Semantics) List[E] {
+	if semantics == BagSemantics {
+		res := slices.Clone(l.elements)
+		counts := countElements(l.elements)
+		for _, e := range other.ToSlice() {
+			if counts[e] > 0 {
+				counts[e]--
+			} else {
+				res = append(res, e)
+			}
+		}
+		return NewList(res...)
+	}
+	res := make([]E, 0)
+	seen := make(map[E]struct{})
+	for _, e := range append(slices.Clone(l.elements), other.ToSlice()...) {
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			res = append(res, e)
+		}
+	}
+	return NewList(res...)
+}
+
+// CompareList compares the elements of the given lists lexicographically.
+// It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func CompareList[E cmp.Ordered](a, b List[E]) int {
+	return slices.Compare(a.ToSlice(), b.ToSlice())
 }
 
 func MapList[E1 comparable, E2 comparable](collection Collection[E1], transform func(E1) E2) List[E2] {
@@ -388,3 +610,81 @@ func MapList[E1 comparable, E2 comparable](collection Collection[E1], transform
 
 	return NewList(result...)
 }
+
+// replaceAll replaces the elements of this list with the given elements.
+func (l *list[E]) replaceAll(elements []E) error {
+	if elements == nil {
+		elements = make([]E, 0)
+	}
+	l.elements = elements
+	l.mod.increment()
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*list[int])(nil)
+	_ json.Unmarshaler = (*list[int])(nil)
+)
+
+// MarshalJSON encodes this list as a JSON array.
+func (l *list[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(l.elements)
+}
+
+// UnmarshalJSON replaces the elements of this list with the decoded JSON array.
+func (l *list[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return l.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*list[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*list[int])(nil)
+	_ gob.GobEncoder             = (*list[int])(nil)
+	_ gob.GobDecoder             = (*list[int])(nil)
+)
+
+// MarshalBinary encodes this list into a compact binary form.
+func (l *list[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(l.elements)
+}
+
+// UnmarshalBinary replaces the elements of this list with the decoded binary form.
+func (l *list[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return l.replaceAll(elements)
+}
+
+// GobEncode encodes this list by MarshalBinary.
+func (l *list[E]) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+// GobDecode decodes this list by UnmarshalBinary.
+func (l *list[E]) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*list[int])(nil)
+	_ fmt.Stringer   = (*list[int])(nil)
+	_ slog.LogValuer = (*list[int])(nil)
+)
+
+func (l *list[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "List", l.elements)
+}
+
+func (l *list[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *list[E]) LogValue() slog.Value {
+	return logCollection("List", l.elements)
+}
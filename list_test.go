@@ -225,7 +225,7 @@ func TestList_Distinct(t *testing.T) {
 	tests := []struct {
 		name string
 		list List[int]
-		want Set[int]
+		want Collection[int]
 	}{
 		{
 			name: "no duplication",
@@ -826,6 +826,193 @@ func TestList_TakeWhile(t *testing.T) {
 	}
 }
 
+func TestList_Subtract(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  List[int]
+		other Iterable[int]
+		want  Set[int]
+	}{
+		{
+			name:  "some elements",
+			list:  NewList(1, 2, 2, 3),
+			other: NewList(2, 4),
+			want:  NewSet(1, 3),
+		},
+		{
+			name:  "all elements",
+			list:  NewList(1, 2),
+			other: NewSet(2, 1),
+			want:  NewSet[int](),
+		},
+		{
+			name:  "no element",
+			list:  NewList(1, 2),
+			other: NewList[int](),
+			want:  NewSet(1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.list.Subtract(tt.other))
+		})
+	}
+}
+
+func TestList_SetOperations(t *testing.T) {
+	l := NewList(1, 2, 2, 3)
+	other := NewList(2, 3, 4)
+	assert.Equal(t, NewSet(1, 2, 3, 4), l.Union(other))
+	assert.Equal(t, NewSet(1, 4), l.SymmetricDifference(other))
+	assert.True(t, l.IsSupersetOf(NewSet(1, 3)))
+	assert.True(t, l.IsSubsetOf(NewSet(1, 2, 3)))
+	assert.True(t, l.ToSet().Equals(NewSet(3, 2, 1)))
+	assert.True(t, l.IsDisjointWith(NewList(5)))
+	assert.True(t, l.ContainsAll(3, 1))
+	assert.False(t, l.ContainsAny(4, 5))
+}
+
+func TestList_OrderedSetOperations(t *testing.T) {
+	l := NewList(3, 1, 2, 1, 3, 3)
+	other := NewList(4, 3, 3, 1)
+	tests := []struct {
+		name      string
+		operation func(other Iterable[int], semantics Semantics) List[int]
+		semantics Semantics
+		want      []int
+	}{
+		{
+			name:      "intersect with set semantics",
+			operation: l.IntersectOrdered,
+			semantics: SetSemantics,
+			want:      []int{3, 1},
+		},
+		{
+			name:      "intersect with bag semantics",
+			operation: l.IntersectOrdered,
+			semantics: BagSemantics,
+			want:      []int{3, 1, 3},
+		},
+		{
+			name:      "union with set semantics",
+			operation: l.UnionOrdered,
+			semantics: SetSemantics,
+			want:      []int{3, 1, 2, 4},
+		},
+		{
+			name:      "union with bag semantics",
+			operation: l.UnionOrdered,
+			semantics: BagSemantics,
+			want:      []int{3, 1, 2, 1, 3, 3, 4},
+		},
+		{
+			name:      "subtract with set semantics",
+			operation: l.SubtractOrdered,
+			semantics: SetSemantics,
+			want:      []int{2},
+		},
+		{
+			name:      "subtract with bag semantics",
+			operation: l.SubtractOrdered,
+			semantics: BagSemantics,
+			want:      []int{2, 1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.operation(other, tt.semantics).ToSlice())
+			assert.Equal(t, []int{3, 1, 2, 1, 3, 3}, l.ToSlice())
+		})
+	}
+}
+
+func TestList_UnionOrdered_BagKeepsMaximum(t *testing.T) {
+	l := NewList("a", "b")
+	got := l.UnionOrdered(NewList("b", "a", "b", "c"), BagSemantics)
+	assert.Equal(t, []string{"a", "b", "b", "c"}, got.ToSlice())
+	assert.Equal(t, []string{"a", "b"}, NewDeque("a").UnionOrdered(NewSet("b"), SetSemantics).ToSlice())
+}
+
+func TestList_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  List[int]
+		other Iterable[int]
+		want  bool
+	}{
+		{
+			name:  "same elements in same order",
+			list:  NewList(1, 2, 2),
+			other: NewList(1, 2, 2),
+			want:  true,
+		},
+		{
+			name:  "different order",
+			list:  NewList(1, 2, 2),
+			other: NewList(2, 1, 2),
+			want:  false,
+		},
+		{
+			name:  "different duplicates",
+			list:  NewList(1, 2, 2),
+			other: NewList(1, 2),
+			want:  false,
+		},
+		{
+			name:  "other list implementation",
+			list:  NewList(1, 2),
+			other: NewDeque(1, 2),
+			want:  true,
+		},
+		{
+			name:  "set",
+			list:  NewList(1, 2),
+			other: NewSet(1, 2),
+			want:  false,
+		},
+		{
+			name:  "empty",
+			list:  NewList[int](),
+			other: NewConcurrentList[int](),
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.list.Equals(tt.other))
+			if tt.want {
+				assert.Equal(t, tt.list.Hash(), tt.other.(List[int]).Hash())
+			}
+		})
+	}
+}
+
+func TestList_Hash(t *testing.T) {
+	assert.Equal(t, NewList("a", "b").Hash(), NewList("a", "b").Hash())
+	assert.NotEqual(t, NewList("a", "b").Hash(), NewList("b", "a").Hash())
+	assert.Equal(t, NewList("a", "b").Hash(), SynchronizedList(NewList("a", "b")).Hash())
+}
+
+func TestCompareList(t *testing.T) {
+	tests := []struct {
+		name string
+		a    List[int]
+		b    List[int]
+		want int
+	}{
+		{name: "equal", a: NewList(1, 2), b: NewList(1, 2), want: 0},
+		{name: "less element", a: NewList(1, 2), b: NewList(1, 3), want: -1},
+		{name: "greater element", a: NewList(2), b: NewList(1, 3), want: 1},
+		{name: "prefix", a: NewList(1), b: NewList(1, 2), want: -1},
+		{name: "empty", a: NewList[int](), b: NewList[int](), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CompareList(tt.a, tt.b))
+		})
+	}
+}
+
 func TestMapList(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -846,3 +1033,11 @@ func TestMapList(t *testing.T) {
 		})
 	}
 }
+
+func TestList_JoinToString(t *testing.T) {
+	assert.Equal(t, "[a, b, c]", NewList("a", "b", "c").JoinToString(", ", "[", "]", -1, ""))
+	assert.Equal(t, "a|b|and more", NewList("a", "b", "c").JoinToString("|", "", "", 2, "and more"))
+	assert.Equal(t, "1 2", NewDeque(1, 2).JoinToString(" ", "", "", -1, ""))
+	assert.Equal(t, "1 2", NewConcurrentList(1, 2).JoinToString(" ", "", "", -1, ""))
+	assert.Equal(t, "1 2", SynchronizedList(NewList(1, 2)).JoinToString(" ", "", "", -1, ""))
+}
@@ -0,0 +1,74 @@
+package kol
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// DefaultLogSampleSize is the default maximum number of elements logged by LogValue of collections.
+const DefaultLogSampleSize = 10
+
+var logSampleSize atomic.Int64
+
+func init() {
+	logSampleSize.Store(DefaultLogSampleSize)
+}
+
+// SetLogSampleSize sets the maximum number of elements logged by LogValue of collections.
+// It panics if n is negative.
+func SetLogSampleSize(n int) {
+	if n < 0 {
+		panic("kol: negative log sample size")
+	}
+	logSampleSize.Store(int64(n))
+}
+
+// logCollection returns a group of the type, the size and the first elements of a collection.
+func logCollection[E comparable](name string, elements []E) slog.Value {
+	n := min(int(logSampleSize.Load()), len(elements))
+	return slog.GroupValue(
+		slog.String("type", typeName[E](name)),
+		slog.Int("size", len(elements)),
+		slog.Any("sample", slices.Clone(elements[:n])),
+	)
+}
+
+// logMap returns a group of the given type name and size, and a sample of the entries of the first keys
+// in the same order as formatMap, whose keys are formatted by fmt.Sprint.
+func logMap[K comparable, V any](typ string, size int, entries map[K]V) slog.Value {
+	keys := sortedForDisplay(maps.Keys(entries))
+	keys = keys[:min(int(logSampleSize.Load()), len(keys))]
+	sample := make(map[string]V, len(keys))
+	for _, k := range keys {
+		sample[fmt.Sprint(k)] = entries[k]
+	}
+	return slog.GroupValue(
+		slog.String("type", typ),
+		slog.Int("size", size),
+		slog.Any("sample", sample),
+	)
+}
+
+// logWrapped returns LogValue of the collection embedded in a wrapper type such as JSONList,
+// or a group of an empty collection of the given type name if it is nil.
+func logWrapped[E comparable](name string, c Collection[E]) slog.Value {
+	if c == nil {
+		return logCollection[E](name, nil)
+	}
+	return c.LogValue()
+}
+
+// typeName returns the name of a generic type instantiated with the element type E.
+func typeName[E any](name string) string {
+	return name + "[" + reflect.TypeFor[E]().String() + "]"
+}
+
+// mapTypeName returns the name of a generic type instantiated with the key type K and the value type V.
+func mapTypeName[K any, V any](name string) string {
+	return name + "[" + reflect.TypeFor[K]().String() + "," + reflect.TypeFor[V]().String() + "]"
+}
@@ -0,0 +1,123 @@
+package kol
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection_LogValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection slog.LogValuer
+		want       string
+	}{
+		{name: "list", collection: NewList(3, 1, 2), want: `{"type":"List[int]","size":3,"sample":[3,1]}`},
+		{name: "empty list", collection: NewList[string](), want: `{"type":"List[string]","size":0,"sample":[]}`},
+		{name: "sampled", collection: NewList(1, 2, 3, 4, 5), want: `{"type":"List[int]","size":5,"sample":[1,2]}`},
+		{name: "set", collection: NewSet(5, 4, 3, 2, 1), want: `{"type":"Set[int]","size":5,"sample":[1,2]}`},
+		{name: "deque", collection: NewDeque(2, 1), want: `{"type":"Deque[int]","size":2,"sample":[2,1]}`},
+		{name: "priority queue", collection: NewPriorityQueue(3, 1, 2), want: `{"type":"PriorityQueue[int]","size":3,"sample":[1,2]}`},
+		{name: "multiset", collection: NewMultiset(2, 2, 1), want: `{"type":"Multiset[int]","size":3,"sample":[1,2]}`},
+		{name: "concurrent set", collection: NewConcurrentSet(3, 2, 1), want: `{"type":"ConcurrentSet[int]","size":3,"sample":[1,2]}`},
+		{name: "expiring set", collection: NewExpiringSet(time.Hour, nil, 1), want: `{"type":"ExpiringSet[int]","size":1,"sample":[1]}`},
+		{name: "synchronized list", collection: SynchronizedList(NewList(1)), want: `{"type":"SynchronizedList[int]","size":1,"sample":[1]}`},
+		{name: "frozen set", collection: NewFrozenSet("b", "a", "c"), want: `{"type":"FrozenSet[string]","size":3,"sample":["a","b"]}`},
+	}
+	SetLogSampleSize(2)
+	defer SetLogSampleSize(DefaultLogSampleSize)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key != "c" {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("", "c", tt.collection)
+			assert.JSONEq(t, `{"c":`+tt.want+`}`, buf.String())
+		})
+	}
+}
+
+func TestMap_LogValue(t *testing.T) {
+	bimap, _ := NewBiMapFromPairs(NewList(NewPair("c", 3), NewPair("b", 2), NewPair("a", 1)))
+	listMultimap := NewListMultimap[int, string]()
+	listMultimap.PutAll(2, "y", "x")
+	listMultimap.Put(1, "z")
+	setMultimap := NewSetMultimap[string, int]()
+	setMultimap.PutAll("a", 3, 1)
+	clock := newFakeClock()
+	c := NewCache(CacheConfig[string, int]{TTL: time.Minute, Clock: clock})
+	c.Put("expired", 0)
+	clock.Advance(time.Minute)
+	c.Put("a", 1)
+
+	tests := []struct {
+		name string
+		m    slog.LogValuer
+		want string
+	}{
+		{name: "bimap", m: bimap, want: `{"type":"BiMap[string,int]","size":3,"sample":{"a":1,"b":2}}`},
+		{name: "empty bimap", m: NewBiMap[string, int](), want: `{"type":"BiMap[string,int]","size":0,"sample":{}}`},
+		{name: "list multimap", m: listMultimap, want: `{"type":"ListMultimap[int,string]","size":3,"sample":{"1":["z"],"2":["y","x"]}}`},
+		{name: "set multimap", m: setMultimap, want: `{"type":"SetMultimap[string,int]","size":2,"sample":{"a":[1,3]}}`},
+		{name: "cache", m: c, want: `{"type":"Cache[string,int]","size":1,"sample":{"a":1}}`},
+		{name: "synchronized cache", m: SynchronizedCache(c), want: `{"type":"Cache[string,int]","size":1,"sample":{"a":1}}`},
+	}
+	SetLogSampleSize(2)
+	defer SetLogSampleSize(DefaultLogSampleSize)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key != "m" {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("", "m", tt.m)
+			assert.JSONEq(t, `{"m":`+tt.want+`}`, buf.String())
+		})
+	}
+	assert.Equal(t, CacheStats{}, c.Stats())
+}
+
+func TestCollection_LogValueText(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("tags", "tags", NewSet("b", "a"))
+	assert.Contains(t, buf.String(), "tags.type=Set[string] tags.size=2 tags.sample=\"[a b]\"")
+}
+
+func TestSequence_LogValue(t *testing.T) {
+	evaluated := 0
+	s := NewSequence(1, 2, 3).Map(func(e int) int {
+		evaluated++
+		return e
+	}).Take(2)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("pipeline", "seq", s)
+	assert.Contains(t, buf.String(), `seq.type=Sequence[int] seq.pipeline="cursor: 0, elements: [1 2 3] > map > take 2"`)
+	assert.Equal(t, 0, evaluated)
+	assert.Equal(t, []int{1, 2}, s.ToSlice())
+}
+
+func TestSetLogSampleSize(t *testing.T) {
+	defer SetLogSampleSize(DefaultLogSampleSize)
+	SetLogSampleSize(0)
+	attrs := NewList(1).LogValue().Group()
+	assert.Equal(t, "sample", attrs[2].Key)
+	assert.Equal(t, []int{}, attrs[2].Value.Any())
+	assert.Panics(t, func() {
+		SetLogSampleSize(-1)
+	})
+}
@@ -0,0 +1,71 @@
+package kol
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrConcurrentModification is reported when a collection is structurally modified during iteration
+// by anything other than the iterator itself.
+var ErrConcurrentModification = errors.New("kol: concurrent modification")
+
+var debugMode atomic.Bool
+
+// SetDebugMode enables or disables the debug mode.
+// In the debug mode, a detected concurrent modification panics with ErrConcurrentModification
+// instead of being reported as an error.
+func SetDebugMode(enabled bool) {
+	debugMode.Store(enabled)
+}
+
+// modCount counts structural modifications of a collection.
+// Collections allocate it lazily when they are iterated for the first time,
+// since only modifications made while an iteration is in progress need to be detected.
+// A nil modCount ignores modifications and never reports them.
+type modCount struct {
+	n int
+}
+
+func (m *modCount) increment() {
+	if m != nil {
+		m.n++
+	}
+}
+
+func (m *modCount) count() int {
+	if m == nil {
+		return 0
+	}
+	return m.n
+}
+
+// check returns ErrConcurrentModification if the collection has been modified
+// since the expected count was observed.
+func (m *modCount) check(expected int) error {
+	if m.count() == expected {
+		return nil
+	}
+	return concurrentModification()
+}
+
+// concurrentModification returns ErrConcurrentModification, or panics with it in the debug mode.
+func concurrentModification() error {
+	if debugMode.Load() {
+		panic(ErrConcurrentModification)
+	}
+	return ErrConcurrentModification
+}
+
+// ForEachErr performs the given action on each element of the given collection, like ForEach.
+// It stops and returns ErrConcurrentModification if the action modifies the collection,
+// instead of panicking like ForEach, unless in the debug mode.
+func ForEachErr[E comparable](collection Iterable[E], action func(element E)) error {
+	iter := collection.Iterator()
+	for {
+		e, ok := iter.Next()
+		if !ok {
+			return iter.Err()
+		}
+		action(e)
+	}
+}
@@ -0,0 +1,191 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+		modify     func(c Collection[int])
+	}{
+		{
+			name:       "add to list",
+			collection: NewList(1, 2, 3),
+			modify:     func(c Collection[int]) { c.Add(4) },
+		},
+		{
+			name:       "remove from list",
+			collection: NewList(1, 2, 3),
+			modify:     func(c Collection[int]) { c.Remove(3) },
+		},
+		{
+			name:       "clear list",
+			collection: NewList(1, 2, 3),
+			modify:     func(c Collection[int]) { c.Clear() },
+		},
+		{
+			name:       "add to set",
+			collection: NewSet(1, 2, 3),
+			modify:     func(c Collection[int]) { c.Add(4) },
+		},
+		{
+			name:       "retain set",
+			collection: NewSet(1, 2, 3),
+			modify:     func(c Collection[int]) { c.Retain(1) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iter := tt.collection.Iterator()
+			_, ok := iter.Next()
+			assert.True(t, ok)
+			assert.NoError(t, iter.Err())
+
+			tt.modify(tt.collection)
+			_, ok = iter.Next()
+			assert.False(t, ok)
+			assert.ErrorIs(t, iter.Err(), ErrConcurrentModification)
+		})
+	}
+
+	t.Run("no-op modification is not detected", func(t *testing.T) {
+		s := NewSet(1, 2, 3)
+		iter := s.Iterator()
+		s.Add(1)
+		s.Remove(4)
+		for iter.HasNext() {
+			iter.Next()
+		}
+		assert.NoError(t, iter.Err())
+	})
+
+	t.Run("modification through the iterator is not detected", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		assert.True(t, iter.Remove())
+		iter.Add(0)
+		e, ok := iter.Next()
+		assert.Equal(t, 2, e)
+		assert.True(t, ok)
+		assert.NoError(t, iter.Err())
+	})
+
+	t.Run("list iterator does not modify after detection", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		iter := l.ListIterator()
+		iter.Next()
+		l.Add(4)
+		assert.False(t, iter.Set(0))
+		assert.False(t, iter.Remove())
+		iter.Add(0)
+		assert.Equal(t, []int{1, 2, 3, 4}, l.ToSlice())
+		assert.ErrorIs(t, iter.Err(), ErrConcurrentModification)
+	})
+}
+
+func TestForEach_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+	}{
+		{name: "list", collection: NewList(1, 2, 3)},
+		{name: "set", collection: NewSet(1, 2, 3)},
+		{name: "deque", collection: NewDeque(1, 2, 3)},
+		{name: "multiset", collection: NewMultiset(1, 2, 3)},
+		{name: "priority queue", collection: NewPriorityQueue(1, 2, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var visited int
+			assert.PanicsWithValue(t, ErrConcurrentModification, func() {
+				tt.collection.ForEach(func(e int) {
+					visited++
+					tt.collection.Add(e * 10)
+				})
+			})
+			assert.Equal(t, 1, visited)
+		})
+	}
+
+	t.Run("modification of another collection", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		other := NewList[int]()
+		assert.NotPanics(t, func() {
+			l.ForEach(func(e int) {
+				other.Add(e)
+			})
+		})
+		assert.Equal(t, []int{1, 2, 3}, other.ToSlice())
+	})
+}
+
+func TestForEachErr(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+	}{
+		{name: "list", collection: NewList(1, 2, 3)},
+		{name: "set", collection: NewSet(1, 2, 3)},
+		{name: "deque", collection: NewDeque(1, 2, 3)},
+		{name: "multiset", collection: NewMultiset(1, 2, 3)},
+		{name: "priority queue", collection: NewPriorityQueue(1, 2, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var visited int
+			err := ForEachErr[int](tt.collection, func(e int) {
+				visited++
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, visited)
+
+			visited = 0
+			assert.NotPanics(t, func() {
+				err = ForEachErr[int](tt.collection, func(e int) {
+					visited++
+					tt.collection.Add(e * 10)
+				})
+			})
+			assert.ErrorIs(t, err, ErrConcurrentModification)
+			assert.Equal(t, 1, visited)
+		})
+	}
+}
+
+func TestSequence_ConcurrentModification(t *testing.T) {
+	l := NewList(1, 2, 3, 4, 5)
+	seq := l.AsSequence().
+		Map(func(e int) int {
+			if e == 2 {
+				l.Remove(5)
+			}
+			return e * 2
+		}).
+		Filter(func(e int) bool { return e > 0 })
+
+	assert.Equal(t, []int{2, 4}, seq.ToSlice())
+	assert.ErrorIs(t, seq.Err(), ErrConcurrentModification)
+	assert.NoError(t, l.AsSequence().Err())
+}
+
+func TestSetDebugMode(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+
+	l := NewList(1, 2, 3)
+	iter := l.Iterator()
+	l.Add(4)
+	assert.PanicsWithValue(t, ErrConcurrentModification, func() {
+		iter.Next()
+	})
+	assert.PanicsWithValue(t, ErrConcurrentModification, func() {
+		l.ForEach(func(e int) {
+			l.Remove(e)
+		})
+	})
+}
@@ -0,0 +1,396 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// Multimap is a collection that maps keys to values, where each key may be associated with multiple values.
+type Multimap[K comparable, V comparable] interface {
+	// Clear removes all entries.
+	Clear()
+	// ContainsEntry returns `true` if the given key is associated with the given value.
+	ContainsEntry(key K, value V) bool
+	// ContainsKey returns `true` if the given key is associated with at least one value.
+	ContainsKey(key K) bool
+	// Entries returns a sequence of all key-value pairs.
+	Entries() Sequence[Pair[K, V]]
+	// Format implements fmt.Formatter.
+	// A multimap is formatted as `{k1: [v1, v2], k2: [v3]}` in a deterministic sorted order of keys.
+	// %+v prefixes the entries with the type and the size,
+	// and %.Nv formats only the first N keys followed by "...and M more".
+	Format(f fmt.State, verb rune)
+	// IsEmpty returns `true` if this multimap has no entries.
+	IsEmpty() bool
+	// Keys returns a multiset of keys, where the count of each key is the number of its values.
+	Keys() Multiset[K]
+	// KeySet returns a set of distinct keys.
+	KeySet() Set[K]
+	// LogValue implements slog.LogValuer, and returns a group of the type, the size
+	// and a sample of the entries limited by SetLogSampleSize, in the same order as Format.
+	LogValue() slog.Value
+	// Put associates the given value with the given key.
+	// It returns `true` if this multimap has changed.
+	Put(key K, value V) bool
+	// PutAll associates the given values with the given key.
+	// It returns `true` if this multimap has changed.
+	PutAll(key K, values ...V) bool
+	// Remove removes an association of the given value with the given key.
+	// It returns `true` if this multimap has changed.
+	Remove(key K, value V) bool
+	// Size returns the number of key-value pairs.
+	Size() int
+	// String returns the keys and their values formatted by %v.
+	String() string
+	// Values returns a list of values of all entries.
+	Values() List[V]
+}
+
+// ListMultimap is a Multimap that holds values of each key in a List,
+// which keeps the insertion order and duplicate values.
+type ListMultimap[K comparable, V comparable] interface {
+	Multimap[K, V]
+
+	// Get returns a copy of values associated with the given key.
+	Get(key K) List[V]
+	// Inverse returns a multimap that maps each value of this multimap to its keys.
+	Inverse() ListMultimap[V, K]
+	// RemoveAll removes all values associated with the given key and returns them.
+	RemoveAll(key K) List[V]
+}
+
+// SetMultimap is a Multimap that holds values of each key in a Set, which has no duplicate values.
+type SetMultimap[K comparable, V comparable] interface {
+	Multimap[K, V]
+
+	// Get returns a copy of values associated with the given key.
+	Get(key K) Set[V]
+	// Inverse returns a multimap that maps each value of this multimap to its keys.
+	Inverse() SetMultimap[V, K]
+	// RemoveAll removes all values associated with the given key and returns them.
+	RemoveAll(key K) Set[V]
+}
+
+type multimap[K comparable, V comparable] struct {
+	m         map[K]Collection[V]
+	size      int
+	newValues func() Collection[V]
+}
+
+func newMultimap[K comparable, V comparable](newValues func() Collection[V]) *multimap[K, V] {
+	return &multimap[K, V]{m: make(map[K]Collection[V]), newValues: newValues}
+}
+
+// removeAll removes values associated with the given key and returns them, or nil if there is no such value.
+func (mm *multimap[K, V]) removeAll(k K) Collection[V] {
+	values, ok := mm.m[k]
+	if !ok {
+		return nil
+	}
+	delete(mm.m, k)
+	mm.size -= values.Size()
+	return values
+}
+
+func (mm *multimap[K, V]) Clear() {
+	clear(mm.m)
+	mm.size = 0
+}
+
+func (mm *multimap[K, V]) ContainsEntry(k K, v V) bool {
+	values, ok := mm.m[k]
+	return ok && values.Contains(v)
+}
+
+func (mm *multimap[K, V]) ContainsKey(k K) bool {
+	_, ok := mm.m[k]
+	return ok
+}
+
+func (mm *multimap[K, V]) Entries() Sequence[Pair[K, V]] {
+	entries := make([]Pair[K, V], 0, mm.size)
+	for k, values := range mm.m {
+		values.ForEach(func(v V) {
+			entries = append(entries, NewPair(k, v))
+		})
+	}
+	return NewSequence(entries...)
+}
+
+func (mm *multimap[K, V]) IsEmpty() bool {
+	return mm.size == 0
+}
+
+func (mm *multimap[K, V]) Keys() Multiset[K] {
+	keys := make(map[K]int, len(mm.m))
+	for k, values := range mm.m {
+		keys[k] = values.Size()
+	}
+	return newMultiset(keys)
+}
+
+func (mm *multimap[K, V]) KeySet() Set[K] {
+	keys := make(map[K]struct{}, len(mm.m))
+	for k := range mm.m {
+		keys[k] = struct{}{}
+	}
+	return newSet(keys)
+}
+
+func (mm *multimap[K, V]) Put(k K, v V) bool {
+	return mm.PutAll(k, v)
+}
+
+func (mm *multimap[K, V]) PutAll(k K, vs ...V) bool {
+	if len(vs) == 0 {
+		return false
+	}
+	values, ok := mm.m[k]
+	if !ok {
+		values = mm.newValues()
+		mm.m[k] = values
+	}
+	size := values.Size()
+	values.Add(vs...)
+	mm.size += values.Size() - size
+	return values.Size() > size
+}
+
+func (mm *multimap[K, V]) Remove(k K, v V) bool {
+	values, ok := mm.m[k]
+	if !ok || !values.Contains(v) {
+		return false
+	}
+	values.Remove(v)
+	mm.size--
+	if values.IsEmpty() {
+		delete(mm.m, k)
+	}
+	return true
+}
+
+func (mm *multimap[K, V]) Size() int {
+	return mm.size
+}
+
+func (mm *multimap[K, V]) Values() List[V] {
+	values := make([]V, 0, mm.size)
+	for _, vs := range mm.m {
+		values = append(values, vs.ToSlice()...)
+	}
+	return NewList(values...)
+}
+
+// displayEntries returns values of each key for Format and LogValue, sorted by sortedForDisplay if sorted is `true`.
+func (mm *multimap[K, V]) displayEntries(sorted bool) map[K]displayList[V] {
+	entries := make(map[K]displayList[V], len(mm.m))
+	for k, values := range mm.m {
+		entries[k] = values.ToSlice()
+		if sorted {
+			sortedForDisplay(entries[k])
+		}
+	}
+	return entries
+}
+
+// MarshalJSON encodes this multimap as a JSON object mapping each key to a JSON array of its values,
+// so keys must be strings, integers or encoding.TextMarshaler.
+func (mm *multimap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mm.m)
+}
+
+// UnmarshalJSON replaces the entries of this multimap with the decoded JSON object of JSON arrays.
+func (mm *multimap[K, V]) UnmarshalJSON(data []byte) error {
+	var m map[K][]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	mm.Clear()
+	for k, values := range m {
+		mm.PutAll(k, values...)
+	}
+	return nil
+}
+
+// MarshalBinary encodes this multimap into a compact binary form.
+// Values of each key are encoded in their iteration order.
+func (mm *multimap[K, V]) MarshalBinary() ([]byte, error) {
+	keys := make([]K, 0, mm.size)
+	values := make([]V, 0, mm.size)
+	for k, vs := range mm.m {
+		vs.ForEach(func(v V) {
+			keys = append(keys, k)
+			values = append(values, v)
+		})
+	}
+	return marshalBinaryEntries(keys, values)
+}
+
+// UnmarshalBinary replaces the entries of this multimap with the decoded binary form.
+func (mm *multimap[K, V]) UnmarshalBinary(data []byte) error {
+	keys, values, err := unmarshalBinaryEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+	mm.Clear()
+	for i, k := range keys {
+		mm.Put(k, values[i])
+	}
+	return nil
+}
+
+// GobEncode encodes this multimap by MarshalBinary.
+func (mm *multimap[K, V]) GobEncode() ([]byte, error) {
+	return mm.MarshalBinary()
+}
+
+// GobDecode decodes this multimap by UnmarshalBinary.
+func (mm *multimap[K, V]) GobDecode(data []byte) error {
+	return mm.UnmarshalBinary(data)
+}
+
+type listMultimap[K comparable, V comparable] struct {
+	*multimap[K, V]
+}
+
+var (
+	_ ListMultimap[int, string]  = (*listMultimap[int, string])(nil)
+	_ json.Marshaler             = (*listMultimap[int, string])(nil)
+	_ json.Unmarshaler           = (*listMultimap[int, string])(nil)
+	_ encoding.BinaryMarshaler   = (*listMultimap[int, string])(nil)
+	_ encoding.BinaryUnmarshaler = (*listMultimap[int, string])(nil)
+	_ gob.GobEncoder             = (*listMultimap[int, string])(nil)
+	_ gob.GobDecoder             = (*listMultimap[int, string])(nil)
+	_ fmt.Formatter              = (*listMultimap[int, string])(nil)
+	_ fmt.Stringer               = (*listMultimap[int, string])(nil)
+	_ slog.LogValuer             = (*listMultimap[int, string])(nil)
+)
+
+// NewListMultimap returns an empty ListMultimap.
+func NewListMultimap[K comparable, V comparable]() ListMultimap[K, V] {
+	return &listMultimap[K, V]{
+		multimap: newMultimap[K](func() Collection[V] {
+			return NewList[V]()
+		}),
+	}
+}
+
+func (mm *listMultimap[K, V]) Get(k K) List[V] {
+	if values, ok := mm.m[k]; ok {
+		return values.ToList()
+	}
+	return NewList[V]()
+}
+
+func (mm *listMultimap[K, V]) Inverse() ListMultimap[V, K] {
+	inverse := NewListMultimap[V, K]()
+	for k, values := range mm.m {
+		values.ForEach(func(v V) {
+			inverse.Put(v, k)
+		})
+	}
+	return inverse
+}
+
+func (mm *listMultimap[K, V]) RemoveAll(k K) List[V] {
+	if values := mm.removeAll(k); values != nil {
+		return values.ToList()
+	}
+	return NewList[V]()
+}
+
+func (mm *listMultimap[K, V]) Format(f fmt.State, verb rune) {
+	formatMap(f, verb, mapTypeName[K, V]("ListMultimap"), mm.size, mm.displayEntries(false))
+}
+
+func (mm *listMultimap[K, V]) String() string {
+	return fmt.Sprint(mm)
+}
+
+func (mm *listMultimap[K, V]) LogValue() slog.Value {
+	return logMap(mapTypeName[K, V]("ListMultimap"), mm.size, mm.displayEntries(false))
+}
+
+type setMultimap[K comparable, V comparable] struct {
+	*multimap[K, V]
+}
+
+var (
+	_ SetMultimap[int, string]   = (*setMultimap[int, string])(nil)
+	_ json.Marshaler             = (*setMultimap[int, string])(nil)
+	_ json.Unmarshaler           = (*setMultimap[int, string])(nil)
+	_ encoding.BinaryMarshaler   = (*setMultimap[int, string])(nil)
+	_ encoding.BinaryUnmarshaler = (*setMultimap[int, string])(nil)
+	_ gob.GobEncoder             = (*setMultimap[int, string])(nil)
+	_ gob.GobDecoder             = (*setMultimap[int, string])(nil)
+	_ fmt.Formatter              = (*setMultimap[int, string])(nil)
+	_ fmt.Stringer               = (*setMultimap[int, string])(nil)
+	_ slog.LogValuer             = (*setMultimap[int, string])(nil)
+)
+
+// NewSetMultimap returns an empty SetMultimap.
+func NewSetMultimap[K comparable, V comparable]() SetMultimap[K, V] {
+	return &setMultimap[K, V]{
+		multimap: newMultimap[K](func() Collection[V] {
+			return NewSet[V]()
+		}),
+	}
+}
+
+func (mm *setMultimap[K, V]) Get(k K) Set[V] {
+	if values, ok := mm.m[k]; ok {
+		return values.ToSet()
+	}
+	return NewSet[V]()
+}
+
+func (mm *setMultimap[K, V]) Inverse() SetMultimap[V, K] {
+	inverse := NewSetMultimap[V, K]()
+	for k, values := range mm.m {
+		values.ForEach(func(v V) {
+			inverse.Put(v, k)
+		})
+	}
+	return inverse
+}
+
+func (mm *setMultimap[K, V]) RemoveAll(k K) Set[V] {
+	if values := mm.removeAll(k); values != nil {
+		return values.ToSet()
+	}
+	return NewSet[V]()
+}
+
+func (mm *setMultimap[K, V]) Format(f fmt.State, verb rune) {
+	formatMap(f, verb, mapTypeName[K, V]("SetMultimap"), mm.size, mm.displayEntries(true))
+}
+
+func (mm *setMultimap[K, V]) String() string {
+	return fmt.Sprint(mm)
+}
+
+func (mm *setMultimap[K, V]) LogValue() slog.Value {
+	return logMap(mapTypeName[K, V]("SetMultimap"), mm.size, mm.displayEntries(true))
+}
+
+// GroupByList groups elements of the given collection by the key returned by the given keySelector function.
+// Elements of each key keep the iteration order of the collection.
+func GroupByList[E comparable, K comparable](collection Iterable[E], keySelector func(E) K) ListMultimap[K, E] {
+	res := NewListMultimap[K, E]()
+	collection.ForEach(func(e E) {
+		res.Put(keySelector(e), e)
+	})
+	return res
+}
+
+// GroupBySet groups distinct elements of the given collection by the key returned by the given keySelector function.
+func GroupBySet[E comparable, K comparable](collection Iterable[E], keySelector func(E) K) SetMultimap[K, E] {
+	res := NewSetMultimap[K, E]()
+	collection.ForEach(func(e E) {
+		res.Put(keySelector(e), e)
+	})
+	return res
+}
@@ -0,0 +1,156 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMultimap_Put(t *testing.T) {
+	mm := NewListMultimap[string, int]()
+	assert.True(t, mm.Put("a", 1))
+	assert.True(t, mm.Put("a", 1))
+	assert.True(t, mm.PutAll("b", 2, 3))
+	assert.False(t, mm.PutAll("c"))
+
+	assert.Equal(t, 4, mm.Size())
+	assert.Equal(t, []int{1, 1}, mm.Get("a").ToSlice())
+	assert.Equal(t, []int{2, 3}, mm.Get("b").ToSlice())
+	assert.True(t, mm.Get("c").IsEmpty())
+	assert.False(t, mm.ContainsKey("c"))
+}
+
+func TestSetMultimap_Put(t *testing.T) {
+	mm := NewSetMultimap[string, int]()
+	assert.True(t, mm.Put("a", 1))
+	assert.False(t, mm.Put("a", 1))
+	assert.True(t, mm.PutAll("a", 1, 2))
+	assert.False(t, mm.PutAll("a", 2))
+
+	assert.Equal(t, 2, mm.Size())
+	assert.ElementsMatch(t, []int{1, 2}, mm.Get("a").ToSlice())
+}
+
+func TestMultimap_Remove(t *testing.T) {
+	tests := []struct {
+		name     string
+		mm       Multimap[string, int]
+		key      string
+		value    int
+		want     bool
+		wantSize int
+		wantKey  bool
+	}{
+		{
+			name:     "list: remove one occurrence",
+			mm:       GroupByList(NewList(1, 1, 2), func(e int) string { return "a" }),
+			key:      "a",
+			value:    1,
+			want:     true,
+			wantSize: 2,
+			wantKey:  true,
+		},
+		{
+			name:     "set: remove last value",
+			mm:       GroupBySet(NewList(1), func(e int) string { return "a" }),
+			key:      "a",
+			value:    1,
+			want:     true,
+			wantSize: 0,
+			wantKey:  false,
+		},
+		{
+			name:     "absent value",
+			mm:       GroupByList(NewList(1), func(e int) string { return "a" }),
+			key:      "a",
+			value:    2,
+			want:     false,
+			wantSize: 1,
+			wantKey:  true,
+		},
+		{
+			name:     "absent key",
+			mm:       NewSetMultimap[string, int](),
+			key:      "a",
+			value:    1,
+			want:     false,
+			wantSize: 0,
+			wantKey:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mm.Remove(tt.key, tt.value))
+			assert.Equal(t, tt.wantSize, tt.mm.Size())
+			assert.Equal(t, tt.wantKey, tt.mm.ContainsKey(tt.key))
+		})
+	}
+}
+
+func TestMultimap_RemoveAll(t *testing.T) {
+	lm := GroupByList(NewList(1, 2, 3, 4, 5), func(e int) bool { return e%2 == 0 })
+	assert.Equal(t, []int{1, 3, 5}, lm.RemoveAll(false).ToSlice())
+	assert.True(t, lm.RemoveAll(false).IsEmpty())
+	assert.Equal(t, 2, lm.Size())
+
+	sm := GroupBySet(NewList(1, 2, 3, 4, 5), func(e int) bool { return e%2 == 0 })
+	assert.ElementsMatch(t, []int{2, 4}, sm.RemoveAll(true).ToSlice())
+	assert.Equal(t, 3, sm.Size())
+}
+
+func TestMultimap_ContainsEntry(t *testing.T) {
+	mm := GroupByList(NewList("apple", "avocado", "banana"), func(e string) byte { return e[0] })
+	assert.True(t, mm.ContainsEntry('a', "avocado"))
+	assert.False(t, mm.ContainsEntry('b', "avocado"))
+	assert.False(t, mm.ContainsEntry('c', "cherry"))
+}
+
+func TestMultimap_Keys(t *testing.T) {
+	mm := GroupByList(NewList("apple", "avocado", "banana"), func(e string) byte { return e[0] })
+	keys := mm.Keys()
+	assert.Equal(t, 2, keys.CountOf('a'))
+	assert.Equal(t, 1, keys.CountOf('b'))
+	assert.Equal(t, 3, keys.Size())
+	assert.ElementsMatch(t, []byte{'a', 'b'}, mm.KeySet().ToSlice())
+}
+
+func TestMultimap_EntriesAndValues(t *testing.T) {
+	mm := NewListMultimap[string, int]()
+	mm.PutAll("a", 1, 2)
+	mm.Put("b", 3)
+	assert.ElementsMatch(t, []Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("a", 2),
+		NewPair("b", 3),
+	}, mm.Entries().ToSlice())
+	assert.ElementsMatch(t, []int{1, 2, 3}, mm.Values().ToSlice())
+
+	mm.Clear()
+	assert.True(t, mm.IsEmpty())
+	assert.Empty(t, mm.Entries().ToSlice())
+}
+
+func TestMultimap_Inverse(t *testing.T) {
+	lm := NewListMultimap[string, int]()
+	lm.PutAll("a", 1, 2, 1)
+	lm.PutAll("b", 1)
+	inverse := lm.Inverse()
+	assert.ElementsMatch(t, []string{"a", "a", "b"}, inverse.Get(1).ToSlice())
+	assert.Equal(t, []string{"a"}, inverse.Get(2).ToSlice())
+	assert.Equal(t, lm.Size(), inverse.Size())
+
+	sm := NewSetMultimap[string, int]()
+	sm.PutAll("a", 1, 2)
+	sm.PutAll("b", 1)
+	assert.ElementsMatch(t, []string{"a", "b"}, sm.Inverse().Get(1).ToSlice())
+}
+
+func TestGroupByList(t *testing.T) {
+	mm := GroupByList(NewSet(1, 2, 3, 4), func(e int) int { return e % 2 })
+	assert.ElementsMatch(t, []int{1, 3}, mm.Get(1).ToSlice())
+	assert.ElementsMatch(t, []int{2, 4}, mm.Get(0).ToSlice())
+
+	ordered := GroupByList(NewList("a", "bb", "cc", "a"), func(e string) int { return len(e) })
+	assert.Equal(t, []string{"a", "a"}, ordered.Get(1).ToSlice())
+	assert.Equal(t, []string{"bb", "cc"}, ordered.Get(2).ToSlice())
+}
@@ -0,0 +1,459 @@
+package kol
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Multiset is an un-ordered collection of elements that may contain duplicate elements, like a bag.
+// It holds each distinct element with its count, so counting occurrences of an element takes O(1) time.
+//
+// The Iterable methods treat each occurrence as a separate element.
+// Intersect, Subtract and Union follow the Iterable contract and return a Set;
+// use IntersectMultiset, Difference, UnionMultiset and Sum to respect counts.
+type Multiset[E comparable] interface {
+	Collection[E]
+
+	// AddN adds n occurrences of the given element.
+	AddN(element E, n int)
+	// CountOf returns the number of occurrences of the given element.
+	CountOf(element E) int
+	// Difference returns a multiset where the count of each element is its count in this multiset
+	// minus its count in the given collection, dropping elements whose count falls to zero or below.
+	Difference(other Iterable[E]) Multiset[E]
+	// ElementSet returns a set of the distinct elements of this multiset.
+	ElementSet() Set[E]
+	// IntersectMultiset returns a multiset where the count of each element is
+	// the minimum of its counts in this multiset and the given collection.
+	IntersectMultiset(other Iterable[E]) Multiset[E]
+	// MostCommon returns at most n pairs of a distinct element and its count, from the most common element.
+	// Elements with equal counts are in arbitrary order. If n is negative, it returns all elements.
+	MostCommon(n int) List[Pair[E, int]]
+	// RemoveN removes at most n occurrences of the given element.
+	RemoveN(element E, n int)
+	// SetCount sets the number of occurrences of the given element, and returns the previous count.
+	// If count is zero or negative, the element is removed.
+	SetCount(element E, count int) int
+	// Sum returns a multiset where the count of each element is the sum of its counts in this multiset and the given collection.
+	Sum(other Iterable[E]) Multiset[E]
+	// UnionMultiset returns a multiset where the count of each element is
+	// the maximum of its counts in this multiset and the given collection.
+	UnionMultiset(other Iterable[E]) Multiset[E]
+}
+
+type multiset[E comparable] struct {
+	m    map[E]int
+	size int
+	mod  *modCount
+}
+
+var _ Multiset[int] = (*multiset[int])(nil)
+
+// NewMultiset returns a Multiset containing the given elements.
+func NewMultiset[E comparable](elements ...E) Multiset[E] {
+	ms := newMultiset[E](make(map[E]int))
+	ms.Add(elements...)
+	return ms
+}
+
+func newMultiset[E comparable](m map[E]int) *multiset[E] {
+	size := 0
+	for _, c := range m {
+		size += c
+	}
+	return &multiset[E]{m: m, size: size}
+}
+
+// countsOf returns counts of elements of the given collection.
+func countsOf[E comparable](c Iterable[E]) map[E]int {
+	if ms, ok := c.(*multiset[E]); ok {
+		return ms.m
+	}
+	return countElements(c.ToSlice())
+}
+
+func (ms *multiset[E]) modification() *modCount {
+	if ms.mod == nil {
+		ms.mod = &modCount{}
+	}
+	return ms.mod
+}
+
+func (ms *multiset[E]) clone() *multiset[E] {
+	return &multiset[E]{m: maps.Clone(ms.m), size: ms.size}
+}
+
+func (ms *multiset[E]) toList() *list[E] {
+	return &list[E]{elements: ms.ToSlice()}
+}
+
+func (ms *multiset[E]) AddN(e E, n int) {
+	if n <= 0 {
+		return
+	}
+	ms.m[e] += n
+	ms.size += n
+	ms.mod.increment()
+}
+
+func (ms *multiset[E]) CountOf(e E) int {
+	return ms.m[e]
+}
+
+func (ms *multiset[E]) Difference(other Iterable[E]) Multiset[E] {
+	res := ms.clone()
+	for e, c := range countsOf(other) {
+		res.RemoveN(e, c)
+	}
+	return res
+}
+
+func (ms *multiset[E]) ElementSet() Set[E] {
+	return NewSet(maps.Keys(ms.m)...)
+}
+
+func (ms *multiset[E]) IntersectMultiset(other Iterable[E]) Multiset[E] {
+	res := make(map[E]int)
+	for e, c := range countsOf(other) {
+		if n := min(ms.m[e], c); n > 0 {
+			res[e] = n
+		}
+	}
+	return newMultiset(res)
+}
+
+func (ms *multiset[E]) MostCommon(n int) List[Pair[E, int]] {
+	entries := make([]Pair[E, int], 0, len(ms.m))
+	for e, c := range ms.m {
+		entries = append(entries, NewPair(e, c))
+	}
+	slices.SortStableFunc(entries, func(a, b Pair[E, int]) int {
+		return cmp.Compare(b.Second, a.Second)
+	})
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return NewList(entries...)
+}
+
+func (ms *multiset[E]) RemoveN(e E, n int) {
+	c, ok := ms.m[e]
+	if !ok || n <= 0 {
+		return
+	}
+	if n >= c {
+		delete(ms.m, e)
+		ms.size -= c
+	} else {
+		ms.m[e] = c - n
+		ms.size -= n
+	}
+	ms.mod.increment()
+}
+
+func (ms *multiset[E]) SetCount(e E, count int) int {
+	prev := ms.m[e]
+	if count > prev {
+		ms.AddN(e, count-prev)
+	} else {
+		ms.RemoveN(e, prev-count)
+	}
+	return prev
+}
+
+func (ms *multiset[E]) Sum(other Iterable[E]) Multiset[E] {
+	res := ms.clone()
+	for e, c := range countsOf(other) {
+		res.AddN(e, c)
+	}
+	return res
+}
+
+func (ms *multiset[E]) UnionMultiset(other Iterable[E]) Multiset[E] {
+	res := ms.clone()
+	for e, c := range countsOf(other) {
+		if c > res.m[e] {
+			res.SetCount(e, c)
+		}
+	}
+	return res
+}
+
+var _ Collection[int] = (*multiset[int])(nil)
+
+func (ms *multiset[E]) Add(elements ...E) {
+	for _, e := range elements {
+		ms.AddN(e, 1)
+	}
+}
+
+func (ms *multiset[E]) Clear() {
+	clear(ms.m)
+	ms.size = 0
+	ms.mod.increment()
+}
+
+func (ms *multiset[E]) IsEmpty() bool {
+	return ms.size == 0
+}
+
+func (ms *multiset[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(ms.ToSlice(), func(e E) {
+		ms.RemoveN(e, 1)
+	}, ms.modification())
+}
+
+// Remove removes one occurrence of each of the given elements.
+func (ms *multiset[E]) Remove(targets ...E) {
+	for _, t := range targets {
+		ms.RemoveN(t, 1)
+	}
+}
+
+// Retain retains all occurrences of elements contained in the given elements.
+func (ms *multiset[E]) Retain(targets ...E) {
+	retained := NewSet(targets...)
+	for e, c := range ms.m {
+		if !retained.Contains(e) {
+			delete(ms.m, e)
+			ms.size -= c
+			ms.mod.increment()
+		}
+	}
+}
+
+func (ms *multiset[E]) Size() int {
+	return ms.size
+}
+
+var _ Iterable[int] = (*multiset[int])(nil)
+
+func (ms *multiset[E]) All(p func(e E) bool) bool {
+	if ms.size == 0 {
+		return false
+	}
+	for e := range ms.m {
+		if !p(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ms *multiset[E]) Any(p func(e E) bool) bool {
+	for e := range ms.m {
+		if p(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ms *multiset[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](ms.Iterator()))
+}
+
+func (ms *multiset[E]) Contains(e E) bool {
+	_, ok := ms.m[e]
+	return ok
+}
+
+func (ms *multiset[E]) ContainsAll(elements ...E) bool {
+	return ms.ElementSet().ContainsAll(elements...)
+}
+
+func (ms *multiset[E]) ContainsAny(elements ...E) bool {
+	return ms.ElementSet().ContainsAny(elements...)
+}
+
+func (ms *multiset[E]) Count(p func(e E) bool) int {
+	count := 0
+	for e, c := range ms.m {
+		if p(e) {
+			count += c
+		}
+	}
+	return count
+}
+
+func (ms *multiset[E]) Distinct() Collection[E] {
+	return ms.ElementSet()
+}
+
+func (ms *multiset[E]) Filter(p func(e E) bool) Collection[E] {
+	filtered := make(map[E]int)
+	for e, c := range ms.m {
+		if p(e) {
+			filtered[e] = c
+		}
+	}
+	return newMultiset(filtered)
+}
+
+func (ms *multiset[E]) Find(p func(e E) bool) (E, bool) {
+	for e := range ms.m {
+		if p(e) {
+			return e, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+func (ms *multiset[E]) ForEach(a func(e E)) {
+	mod := ms.modification()
+	expected := mod.count()
+	for _, e := range ms.ToSlice() {
+		a(e)
+		if err := mod.check(expected); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (ms *multiset[E]) Intersect(other Iterable[E]) Set[E] {
+	return ms.ElementSet().Intersect(other)
+}
+
+func (ms *multiset[E]) IsDisjointWith(other Iterable[E]) bool {
+	return ms.ElementSet().IsDisjointWith(other)
+}
+
+func (ms *multiset[E]) IsSubsetOf(other Iterable[E]) bool {
+	return ms.ElementSet().IsSubsetOf(other)
+}
+
+func (ms *multiset[E]) IsSupersetOf(other Iterable[E]) bool {
+	return ms.ElementSet().IsSupersetOf(other)
+}
+
+func (ms *multiset[E]) Iterator() Iterator[E] {
+	return ms.MutableIterator()
+}
+
+func (ms *multiset[E]) Map(t func(e E) E) Collection[E] {
+	mapped := make(map[E]int)
+	for e, c := range ms.m {
+		mapped[t(e)] += c
+	}
+	return newMultiset(mapped)
+}
+
+func (ms *multiset[E]) Minus(e ...E) Collection[E] {
+	res := ms.clone()
+	res.Remove(e...)
+	return res
+}
+
+func (ms *multiset[E]) None(p func(e E) bool) bool {
+	return !ms.Any(p)
+}
+
+func (ms *multiset[E]) Plus(e ...E) Collection[E] {
+	res := ms.clone()
+	res.Add(e...)
+	return res
+}
+
+func (ms *multiset[E]) Single(p func(e E) bool) (E, bool) {
+	return ms.toList().Single(p)
+}
+
+func (ms *multiset[E]) Subtract(other Iterable[E]) Set[E] {
+	return ms.ElementSet().Subtract(other)
+}
+
+func (ms *multiset[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return ms.ElementSet().SymmetricDifference(other)
+}
+
+func (ms *multiset[E]) ToList() List[E] {
+	return ms.toList()
+}
+
+func (ms *multiset[E]) ToSet() Set[E] {
+	return ms.ElementSet()
+}
+
+// ToSlice returns a slice containing each element as many times as its count.
+func (ms *multiset[E]) ToSlice() []E {
+	res := make([]E, 0, ms.size)
+	for e, c := range ms.m {
+		for range c {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func (ms *multiset[E]) Union(other Iterable[E]) Set[E] {
+	return ms.ElementSet().Union(other)
+}
+
+var (
+	_ json.Marshaler   = (*multiset[int])(nil)
+	_ json.Unmarshaler = (*multiset[int])(nil)
+)
+
+// MarshalJSON encodes this multiset as a JSON array containing each element as many times as its count,
+// in arbitrary order.
+func (ms *multiset[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(ms.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of this multiset with the decoded JSON array.
+func (ms *multiset[E]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONInto[E](ms, data)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*multiset[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*multiset[int])(nil)
+	_ gob.GobEncoder             = (*multiset[int])(nil)
+	_ gob.GobDecoder             = (*multiset[int])(nil)
+)
+
+// MarshalBinary encodes this multiset into a compact binary form containing each element as many times as its count.
+func (ms *multiset[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(ms.ToSlice())
+}
+
+// UnmarshalBinary replaces the elements of this multiset with the decoded binary form.
+func (ms *multiset[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryInto[E](ms, data)
+}
+
+// GobEncode encodes this multiset by MarshalBinary.
+func (ms *multiset[E]) GobEncode() ([]byte, error) {
+	return ms.MarshalBinary()
+}
+
+// GobDecode decodes this multiset by UnmarshalBinary.
+func (ms *multiset[E]) GobDecode(data []byte) error {
+	return ms.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*multiset[int])(nil)
+	_ fmt.Stringer   = (*multiset[int])(nil)
+	_ slog.LogValuer = (*multiset[int])(nil)
+)
+
+func (ms *multiset[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "Multiset", sortedForDisplay(ms.ToSlice()))
+}
+
+func (ms *multiset[E]) String() string {
+	return fmt.Sprint(ms)
+}
+
+func (ms *multiset[E]) LogValue() slog.Value {
+	return logCollection("Multiset", sortedForDisplay(ms.ToSlice()))
+}
@@ -0,0 +1,155 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiset_CountOf(t *testing.T) {
+	ms := NewMultiset("a", "b", "a", "c", "a")
+	assert.Equal(t, 3, ms.CountOf("a"))
+	assert.Equal(t, 1, ms.CountOf("b"))
+	assert.Equal(t, 0, ms.CountOf("d"))
+	assert.Equal(t, 5, ms.Size())
+}
+
+func TestMultiset_AddNAndRemoveN(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(ms Multiset[string])
+		wantCount int
+		wantSize  int
+	}{
+		{
+			name:      "add n",
+			modify:    func(ms Multiset[string]) { ms.AddN("a", 3) },
+			wantCount: 5,
+			wantSize:  6,
+		},
+		{
+			name:      "add non-positive n",
+			modify:    func(ms Multiset[string]) { ms.AddN("a", -1) },
+			wantCount: 2,
+			wantSize:  3,
+		},
+		{
+			name:      "remove n",
+			modify:    func(ms Multiset[string]) { ms.RemoveN("a", 1) },
+			wantCount: 1,
+			wantSize:  2,
+		},
+		{
+			name:      "remove more than count",
+			modify:    func(ms Multiset[string]) { ms.RemoveN("a", 5) },
+			wantCount: 0,
+			wantSize:  1,
+		},
+		{
+			name:      "remove one occurrence",
+			modify:    func(ms Multiset[string]) { ms.Remove("a", "b") },
+			wantCount: 1,
+			wantSize:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := NewMultiset("a", "a", "b")
+			tt.modify(ms)
+			assert.Equal(t, tt.wantCount, ms.CountOf("a"))
+			assert.Equal(t, tt.wantSize, ms.Size())
+		})
+	}
+}
+
+func TestMultiset_SetCount(t *testing.T) {
+	ms := NewMultiset("a", "a", "b")
+	assert.Equal(t, 2, ms.SetCount("a", 5))
+	assert.Equal(t, 6, ms.Size())
+	assert.Equal(t, 1, ms.SetCount("b", 0))
+	assert.False(t, ms.Contains("b"))
+	assert.Equal(t, 0, ms.SetCount("c", 1))
+	assert.Equal(t, 6, ms.Size())
+	assert.Equal(t, NewSet("a", "c"), ms.ElementSet())
+}
+
+func TestMultiset_MostCommon(t *testing.T) {
+	ms := NewMultiset("a", "b", "b", "c", "c", "c")
+	assert.Equal(t, NewList(NewPair("c", 3), NewPair("b", 2)), ms.MostCommon(2))
+	assert.Equal(t, 3, ms.MostCommon(-1).Size())
+	assert.Equal(t, 3, ms.MostCommon(10).Size())
+}
+
+func TestMultiset_CountOperations(t *testing.T) {
+	a := NewMultiset(1, 1, 1, 2, 3)
+	b := NewMultiset(1, 2, 2, 4)
+	tests := []struct {
+		name string
+		got  Multiset[int]
+		want map[int]int
+	}{
+		{
+			name: "union",
+			got:  a.UnionMultiset(b),
+			want: map[int]int{1: 3, 2: 2, 3: 1, 4: 1},
+		},
+		{
+			name: "intersect",
+			got:  a.IntersectMultiset(b),
+			want: map[int]int{1: 1, 2: 1},
+		},
+		{
+			name: "sum",
+			got:  a.Sum(b),
+			want: map[int]int{1: 4, 2: 3, 3: 1, 4: 1},
+		},
+		{
+			name: "difference",
+			got:  a.Difference(b),
+			want: map[int]int{1: 2, 3: 1},
+		},
+		{
+			name: "difference with list",
+			got:  a.Difference(NewList(1, 1, 1, 1, 3)),
+			want: map[int]int{2: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := 0
+			for e, c := range tt.want {
+				assert.Equal(t, c, tt.got.CountOf(e), "count of %d", e)
+				size += c
+			}
+			assert.Equal(t, size, tt.got.Size())
+		})
+	}
+	assert.Equal(t, 5, a.Size())
+	assert.Equal(t, 4, b.Size())
+}
+
+func TestMultiset_Collection(t *testing.T) {
+	ms := NewMultiset(1, 1, 2, 3, 3, 3)
+	assert.Equal(t, 5, ms.Count(func(e int) bool { return e != 2 }))
+	assert.True(t, ms.All(func(e int) bool { return e < 4 }))
+	assert.ElementsMatch(t, []int{1, 1, 2, 3, 3, 3}, ms.ToSlice())
+	assert.ElementsMatch(t, []int{1, 2, 3}, ms.Distinct().ToSlice())
+	assert.ElementsMatch(t, []int{1, 1, 3, 3, 3}, ms.Filter(func(e int) bool { return e%2 == 1 }).ToSlice())
+	assert.ElementsMatch(t, []int{0, 0, 0, 0, 0, 0}, ms.Map(func(_ int) int { return 0 }).ToSlice())
+	assert.ElementsMatch(t, []int{1, 2, 3, 3}, ms.Minus(1, 3).ToSlice())
+	assert.Equal(t, 6, ms.Size())
+
+	ms.Retain(1, 2)
+	assert.ElementsMatch(t, []int{1, 1, 2}, ms.ToSlice())
+
+	iter := ms.MutableIterator()
+	for iter.HasNext() {
+		if e, _ := iter.Next(); e == 1 {
+			assert.True(t, iter.Remove())
+		}
+	}
+	assert.Equal(t, []int{2}, ms.ToSlice())
+
+	ms.Clear()
+	assert.True(t, ms.IsEmpty())
+}
@@ -0,0 +1,20 @@
+package kol
+
+import "fmt"
+
+// Pair is a pair of two values.
+type Pair[A comparable, B comparable] struct {
+	First  A
+	Second B
+}
+
+// NewPair returns a Pair of the given values.
+func NewPair[A comparable, B comparable](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+var _ fmt.Stringer = Pair[int, int]{}
+
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
@@ -0,0 +1,12 @@
+package kol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair_String(t *testing.T) {
+	assert.Equal(t, "(a, 1)", fmt.Sprint(NewPair("a", 1)))
+}
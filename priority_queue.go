@@ -0,0 +1,474 @@
+package kol
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/exp/slices"
+)
+
+// PriorityQueue is a collection that returns elements in priority order, backed by a binary heap.
+// Iterating over it and converting it into a list or slice do not follow the priority order.
+// Use Pop or Drain to get elements in the priority order.
+type PriorityQueue[E comparable] interface {
+	Collection[E]
+
+	// Drain returns a sequence that lazily pops elements from this queue in the priority order.
+	Drain() Sequence[E]
+	// Fix re-establishes the ordering after the priority of the element referred by the given handle has changed,
+	// e.g. a field of a pointer element used by the comparator has been modified.
+	// It returns `false` if the element is no longer in this queue.
+	Fix(handle *PriorityQueueHandle[E]) bool
+	// Merge pushes all elements of the given collection into this queue.
+	Merge(other Iterable[E])
+	// Peek returns the element with the highest priority without removing it.
+	// If this queue is empty, it returns `false` as a second return value.
+	Peek() (E, bool)
+	// Pop removes and returns the element with the highest priority.
+	// If this queue is empty, it returns `false` as a second return value.
+	Pop() (E, bool)
+	// Push pushes the given element and returns a handle to update it afterwards.
+	Push(element E) *PriorityQueueHandle[E]
+	// Update replaces the element referred by the given handle with the given element.
+	// It returns `false` if the element is no longer in this queue.
+	Update(handle *PriorityQueueHandle[E], element E) bool
+}
+
+// PriorityQueueHandle refers to an element pushed into a PriorityQueue.
+type PriorityQueueHandle[E comparable] struct {
+	value E
+	index int
+	queue *priorityQueue[E]
+}
+
+// Value returns the element referred by this handle.
+func (h *PriorityQueueHandle[E]) Value() E {
+	return h.value
+}
+
+type priorityQueue[E comparable] struct {
+	items   []*PriorityQueueHandle[E]
+	compare func(a, b E) int
+	mod     *modCount
+}
+
+var _ PriorityQueue[int] = (*priorityQueue[int])(nil)
+
+// NewPriorityQueue returns a PriorityQueue containing the given elements,
+// which returns the smallest element first.
+func NewPriorityQueue[E cmp.Ordered](elements ...E) PriorityQueue[E] {
+	return NewPriorityQueueFunc(cmp.Compare[E], elements...)
+}
+
+// NewPriorityQueueFunc returns a PriorityQueue containing the given elements,
+// which returns the smallest element first according to the given compare function.
+// The compare function returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func NewPriorityQueueFunc[E comparable](compare func(a, b E) int, elements ...E) PriorityQueue[E] {
+	q := &priorityQueue[E]{items: make([]*PriorityQueueHandle[E], 0, len(elements)), compare: compare}
+	q.append(elements...)
+	heap.Init((*priorityQueueHeap[E])(q))
+	return q
+}
+
+func (q *priorityQueue[E]) modification() *modCount {
+	if q.mod == nil {
+		q.mod = &modCount{}
+	}
+	return q.mod
+}
+
+// append appends the given elements without re-establishing the heap ordering.
+func (q *priorityQueue[E]) append(elements ...E) {
+	for _, e := range elements {
+		q.items = append(q.items, &PriorityQueueHandle[E]{value: e, index: len(q.items), queue: q})
+	}
+}
+
+func (q *priorityQueue[E]) values() []E {
+	values := make([]E, len(q.items))
+	for i, item := range q.items {
+		values[i] = item.value
+	}
+	return values
+}
+
+// sortedValues returns the elements in the priority order.
+func (q *priorityQueue[E]) sortedValues() []E {
+	values := q.values()
+	slices.SortStableFunc(values, q.compare)
+	return values
+}
+
+func (q *priorityQueue[E]) toList() *list[E] {
+	return &list[E]{elements: q.values()}
+}
+
+func (q *priorityQueue[E]) owns(h *PriorityQueueHandle[E]) bool {
+	return h != nil && h.queue == q && h.index >= 0
+}
+
+// remove removes the element referred by the given handle.
+// It returns `false` if the element is no longer in this queue.
+func (q *priorityQueue[E]) remove(h *PriorityQueueHandle[E]) bool {
+	if !q.owns(h) {
+		return false
+	}
+	heap.Remove((*priorityQueueHeap[E])(q), h.index)
+	q.mod.increment()
+	return true
+}
+
+func (q *priorityQueue[E]) Drain() Sequence[E] {
+	return newSequence[E](newDrainSequence[E](q))
+}
+
+func (q *priorityQueue[E]) Fix(h *PriorityQueueHandle[E]) bool {
+	if !q.owns(h) {
+		return false
+	}
+	heap.Fix((*priorityQueueHeap[E])(q), h.index)
+	return true
+}
+
+func (q *priorityQueue[E]) Merge(other Iterable[E]) {
+	elements := other.ToSlice()
+	if len(elements) == 0 {
+		return
+	}
+	q.append(elements...)
+	heap.Init((*priorityQueueHeap[E])(q))
+	q.mod.increment()
+}
+
+func (q *priorityQueue[E]) Peek() (E, bool) {
+	if len(q.items) == 0 {
+		var zero E
+		return zero, false
+	}
+	return q.items[0].value, true
+}
+
+func (q *priorityQueue[E]) Pop() (E, bool) {
+	if len(q.items) == 0 {
+		var zero E
+		return zero, false
+	}
+	h := heap.Pop((*priorityQueueHeap[E])(q)).(*PriorityQueueHandle[E]) //nolint:forcetypeassert
+	q.mod.increment()
+	return h.value, true
+}
+
+func (q *priorityQueue[E]) Push(e E) *PriorityQueueHandle[E] {
+	h := &PriorityQueueHandle[E]{value: e, queue: q}
+	heap.Push((*priorityQueueHeap[E])(q), h)
+	q.mod.increment()
+	return h
+}
+
+func (q *priorityQueue[E]) Update(h *PriorityQueueHandle[E], e E) bool {
+	if !q.owns(h) {
+		return false
+	}
+	h.value = e
+	heap.Fix((*priorityQueueHeap[E])(q), h.index)
+	return true
+}
+
+var _ Collection[int] = (*priorityQueue[int])(nil)
+
+func (q *priorityQueue[E]) Add(elements ...E) {
+	for _, e := range elements {
+		q.Push(e)
+	}
+}
+
+func (q *priorityQueue[E]) Clear() {
+	for _, item := range q.items {
+		item.index = -1
+	}
+	q.items = make([]*PriorityQueueHandle[E], 0)
+	q.mod.increment()
+}
+
+func (q *priorityQueue[E]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+func (q *priorityQueue[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(q.values(), func(e E) {
+		q.Remove(e)
+	}, q.modification())
+}
+
+func (q *priorityQueue[E]) Remove(targets ...E) {
+	for _, t := range targets {
+		for i, item := range q.items {
+			if item.value == t {
+				heap.Remove((*priorityQueueHeap[E])(q), i)
+				q.mod.increment()
+				break
+			}
+		}
+	}
+}
+
+func (q *priorityQueue[E]) Retain(targets ...E) {
+	tl := NewList(targets...)
+	retained := make([]*PriorityQueueHandle[E], 0, len(q.items))
+	for _, item := range q.items {
+		if tl.Contains(item.value) {
+			item.index = len(retained)
+			retained = append(retained, item)
+		} else {
+			item.index = -1
+		}
+	}
+	if len(retained) == len(q.items) {
+		return
+	}
+	q.items = retained
+	heap.Init((*priorityQueueHeap[E])(q))
+	q.mod.increment()
+}
+
+func (q *priorityQueue[E]) Size() int {
+	return len(q.items)
+}
+
+var _ Iterable[int] = (*priorityQueue[int])(nil)
+
+func (q *priorityQueue[E]) All(p func(e E) bool) bool {
+	return q.toList().All(p)
+}
+
+func (q *priorityQueue[E]) Any(p func(e E) bool) bool {
+	return q.toList().Any(p)
+}
+
+func (q *priorityQueue[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](q.Iterator()))
+}
+
+func (q *priorityQueue[E]) Contains(e E) bool {
+	return q.toList().Contains(e)
+}
+
+func (q *priorityQueue[E]) ContainsAll(elements ...E) bool {
+	return q.toList().ContainsAll(elements...)
+}
+
+func (q *priorityQueue[E]) ContainsAny(elements ...E) bool {
+	return q.toList().ContainsAny(elements...)
+}
+
+func (q *priorityQueue[E]) Count(p func(e E) bool) int {
+	return q.toList().Count(p)
+}
+
+func (q *priorityQueue[E]) Distinct() Collection[E] {
+	return q.toList().Distinct()
+}
+
+func (q *priorityQueue[E]) Filter(p func(e E) bool) Collection[E] {
+	return q.toList().Filter(p)
+}
+
+func (q *priorityQueue[E]) Find(p func(e E) bool) (E, bool) {
+	return q.toList().Find(p)
+}
+
+func (q *priorityQueue[E]) ForEach(a func(e E)) {
+	mod := q.modification()
+	expected := mod.count()
+	for _, e := range q.values() {
+		a(e)
+		if err := mod.check(expected); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (q *priorityQueue[E]) Intersect(other Iterable[E]) Set[E] {
+	return q.toList().Intersect(other)
+}
+
+func (q *priorityQueue[E]) IsDisjointWith(other Iterable[E]) bool {
+	return q.toList().IsDisjointWith(other)
+}
+
+func (q *priorityQueue[E]) IsSubsetOf(other Iterable[E]) bool {
+	return q.toList().IsSubsetOf(other)
+}
+
+func (q *priorityQueue[E]) IsSupersetOf(other Iterable[E]) bool {
+	return q.toList().IsSupersetOf(other)
+}
+
+func (q *priorityQueue[E]) Iterator() Iterator[E] {
+	return q.MutableIterator()
+}
+
+func (q *priorityQueue[E]) Map(t func(e E) E) Collection[E] {
+	return q.toList().Map(t)
+}
+
+func (q *priorityQueue[E]) Minus(e ...E) Collection[E] {
+	return q.toList().Minus(e...)
+}
+
+func (q *priorityQueue[E]) None(p func(e E) bool) bool {
+	return q.toList().None(p)
+}
+
+func (q *priorityQueue[E]) Plus(e ...E) Collection[E] {
+	return q.toList().Plus(e...)
+}
+
+func (q *priorityQueue[E]) Single(p func(e E) bool) (E, bool) {
+	return q.toList().Single(p)
+}
+
+func (q *priorityQueue[E]) Subtract(other Iterable[E]) Set[E] {
+	return q.toList().Subtract(other)
+}
+
+func (q *priorityQueue[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return q.toList().SymmetricDifference(other)
+}
+
+func (q *priorityQueue[E]) ToList() List[E] {
+	return q.toList()
+}
+
+func (q *priorityQueue[E]) ToSet() Set[E] {
+	return NewSet(q.values()...)
+}
+
+func (q *priorityQueue[E]) ToSlice() []E {
+	return q.values()
+}
+
+func (q *priorityQueue[E]) Union(other Iterable[E]) Set[E] {
+	return q.toList().Union(other)
+}
+
+// priorityQueueHeap implements heap.Interface for priorityQueue.
+type priorityQueueHeap[E comparable] priorityQueue[E]
+
+var _ heap.Interface = (*priorityQueueHeap[int])(nil)
+
+func (h *priorityQueueHeap[E]) Len() int {
+	return len(h.items)
+}
+
+func (h *priorityQueueHeap[E]) Less(i, j int) bool {
+	return h.compare(h.items[i].value, h.items[j].value) < 0
+}
+
+func (h *priorityQueueHeap[E]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *priorityQueueHeap[E]) Push(x any) {
+	item := x.(*PriorityQueueHandle[E]) //nolint:forcetypeassert
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *priorityQueueHeap[E]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items[n-1] = nil
+	h.items = h.items[:n-1]
+	item.index = -1
+	return item
+}
+
+type drainSequence[E comparable] struct {
+	queue *priorityQueue[E]
+}
+
+var _ seq[int] = (*drainSequence[int])(nil)
+
+func newDrainSequence[E comparable](queue *priorityQueue[E]) seq[E] {
+	return &drainSequence[E]{queue: queue}
+}
+
+func (s *drainSequence[E]) Next() (E, bool) {
+	return s.queue.Pop()
+}
+
+func (s *drainSequence[E]) Err() error {
+	return nil
+}
+
+func (s *drainSequence[E]) String() string {
+	return fmt.Sprintf("drain: %v", s.queue.values())
+}
+
+var (
+	_ json.Marshaler   = (*priorityQueue[int])(nil)
+	_ json.Unmarshaler = (*priorityQueue[int])(nil)
+)
+
+// MarshalJSON encodes this queue as a JSON array. The elements are not in the priority order.
+func (q *priorityQueue[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(q.values())
+}
+
+// UnmarshalJSON replaces the elements of this queue with the decoded JSON array.
+func (q *priorityQueue[E]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONInto[E](q, data)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*priorityQueue[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*priorityQueue[int])(nil)
+	_ gob.GobEncoder             = (*priorityQueue[int])(nil)
+	_ gob.GobDecoder             = (*priorityQueue[int])(nil)
+)
+
+// MarshalBinary encodes this queue into a compact binary form. The elements are not in the priority order.
+func (q *priorityQueue[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(q.values())
+}
+
+// UnmarshalBinary replaces the elements of this queue with the decoded binary form.
+func (q *priorityQueue[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryInto[E](q, data)
+}
+
+// GobEncode encodes this queue by MarshalBinary.
+func (q *priorityQueue[E]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode decodes this queue by UnmarshalBinary.
+func (q *priorityQueue[E]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*priorityQueue[int])(nil)
+	_ fmt.Stringer   = (*priorityQueue[int])(nil)
+	_ slog.LogValuer = (*priorityQueue[int])(nil)
+)
+
+func (q *priorityQueue[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "PriorityQueue", q.sortedValues())
+}
+
+func (q *priorityQueue[E]) String() string {
+	return fmt.Sprint(q)
+}
+
+func (q *priorityQueue[E]) LogValue() slog.Value {
+	return logCollection("PriorityQueue", q.sortedValues())
+}
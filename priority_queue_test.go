@@ -0,0 +1,133 @@
+package kol
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue_Pop(t *testing.T) {
+	tests := []struct {
+		name  string
+		queue PriorityQueue[int]
+		want  []int
+	}{
+		{
+			name:  "ordered",
+			queue: NewPriorityQueue(5, 1, 4, 2, 3, 1),
+			want:  []int{1, 1, 2, 3, 4, 5},
+		},
+		{
+			name:  "comparator",
+			queue: NewPriorityQueueFunc(func(a, b int) int { return cmp.Compare(b, a) }, 5, 1, 4, 2, 3),
+			want:  []int{5, 4, 3, 2, 1},
+		},
+		{
+			name:  "empty queue",
+			queue: NewPriorityQueue[int](),
+			want:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]int, 0)
+			for {
+				e, ok := tt.queue.Pop()
+				if !ok {
+					break
+				}
+				got = append(got, e)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.True(t, tt.queue.IsEmpty())
+		})
+	}
+}
+
+func TestPriorityQueue_PushAndPeek(t *testing.T) {
+	q := NewPriorityQueue[string]()
+	_, ok := q.Peek()
+	assert.False(t, ok)
+
+	q.Push("b")
+	q.Push("c")
+	q.Push("a")
+	e, ok := q.Peek()
+	assert.Equal(t, "a", e)
+	assert.True(t, ok)
+	assert.Equal(t, 3, q.Size())
+}
+
+func TestPriorityQueue_UpdateAndFix(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	q := NewPriorityQueueFunc(func(a, b *task) int { return cmp.Compare(a.priority, b.priority) })
+	low := &task{name: "low", priority: 10}
+	high := &task{name: "high", priority: 1}
+	lowHandle := q.Push(low)
+	q.Push(high)
+	e, _ := q.Peek()
+	assert.Equal(t, high, e)
+
+	low.priority = 0
+	assert.True(t, q.Fix(lowHandle))
+	e, _ = q.Peek()
+	assert.Equal(t, low, e)
+
+	replaced := &task{name: "replaced", priority: 5}
+	assert.True(t, q.Update(lowHandle, replaced))
+	assert.Equal(t, replaced, lowHandle.Value())
+	assert.Equal(t, []*task{high, replaced}, q.Drain().ToSlice())
+
+	assert.False(t, q.Fix(lowHandle))
+	assert.False(t, q.Update(lowHandle, low))
+	assert.False(t, NewPriorityQueueFunc(func(a, b *task) int { return 0 }).Fix(lowHandle))
+}
+
+func TestPriorityQueue_Merge(t *testing.T) {
+	q := NewPriorityQueue(3, 1)
+	q.Merge(NewPriorityQueue(4, 2))
+	q.Merge(NewList(0))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, q.Drain().ToSlice())
+}
+
+func TestPriorityQueue_Drain(t *testing.T) {
+	q := NewPriorityQueue(5, 3, 1, 4, 2)
+	assert.Equal(t, []int{2, 4}, q.Drain().Filter(func(e int) bool { return e%2 == 0 }).ToSlice())
+	assert.True(t, q.IsEmpty())
+
+	q.Add(5, 3, 1)
+	assert.Equal(t, []int{1}, q.Drain().Take(1).ToSlice())
+	assert.Equal(t, 2, q.Size())
+}
+
+func TestPriorityQueue_Collection(t *testing.T) {
+	q := NewPriorityQueue(5, 3, 1, 4, 2)
+	assert.True(t, q.Any(func(e int) bool { return e > 4 }))
+	assert.True(t, q.Contains(3))
+	assert.ElementsMatch(t, []int{2, 4}, q.Filter(func(e int) bool { return e%2 == 0 }).ToSlice())
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, q.ToList().ToSlice())
+
+	q.Remove(1, 4)
+	assert.Equal(t, 3, q.Size())
+	e, _ := q.Peek()
+	assert.Equal(t, 2, e)
+
+	q.Retain(3, 5)
+	assert.Equal(t, []int{3, 5}, q.Drain().ToSlice())
+
+	q.Add(2, 1)
+	iter := q.MutableIterator()
+	for iter.HasNext() {
+		if e, _ := iter.Next(); e == 1 {
+			assert.True(t, iter.Remove())
+		}
+	}
+	assert.Equal(t, []int{2}, q.ToSlice())
+
+	q.Clear()
+	assert.True(t, q.IsEmpty())
+}
@@ -0,0 +1,180 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// OverflowPolicy decides what a RingBuffer does when an element is added while it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowOverwrite evicts the oldest element to make room for the new element.
+	OverflowOverwrite OverflowPolicy = iota
+	// OverflowReject discards the new element.
+	OverflowReject
+)
+
+// RingBuffer is a Deque with a fixed capacity, which never grows.
+// Once it is full, adding an element evicts the oldest element or is rejected according to its OverflowPolicy.
+type RingBuffer[E comparable] interface {
+	Deque[E]
+
+	// Capacity returns the maximum number of elements this buffer holds.
+	Capacity() int
+	// IsFull returns `true` if this buffer holds as many elements as its capacity.
+	IsFull() bool
+	// Offer adds the given element at the end of this buffer.
+	// It returns `false` if the element has been rejected because this buffer is full.
+	Offer(element E) bool
+	// Snapshot returns a sequence over a copy of the current elements.
+	Snapshot() Sequence[E]
+}
+
+type ringBuffer[E comparable] struct {
+	*deque[E]
+	capacity int
+	policy   OverflowPolicy
+	onEvict  func(evicted E)
+}
+
+var _ RingBuffer[int] = (*ringBuffer[int])(nil)
+
+// NewRingBuffer returns an empty RingBuffer with the given capacity and overflow policy.
+// It panics if capacity is not positive.
+func NewRingBuffer[E comparable](capacity int, policy OverflowPolicy) RingBuffer[E] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("kol: non-positive capacity of RingBuffer: %d", capacity))
+	}
+	return &ringBuffer[E]{
+		deque:    &deque[E]{buf: make([]E, capacity)},
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// NewRingBufferFunc returns an empty RingBuffer with the given capacity,
+// which evicts the oldest element when it is full and calls the given function with the evicted element.
+// It panics if capacity is not positive.
+func NewRingBufferFunc[E comparable](capacity int, onEvict func(evicted E)) RingBuffer[E] {
+	r := NewRingBuffer[E](capacity, OverflowOverwrite).(*ringBuffer[E]) //nolint:forcetypeassert
+	r.onEvict = onEvict
+	return r
+}
+
+// offer inserts the given element at the given index, applying the overflow policy if this buffer is full.
+// It returns `false` if the element has been rejected.
+func (r *ringBuffer[E]) offer(idx int, e E) bool {
+	if r.size < r.capacity {
+		r.deque.insert(idx, e)
+		return true
+	}
+	if r.policy == OverflowReject {
+		return false
+	}
+	evicted := e
+	if idx > 0 {
+		evicted = r.at(0)
+		r.deque.removeAt(0)
+		r.deque.insert(idx-1, e)
+	}
+	if r.onEvict != nil {
+		r.onEvict(evicted)
+	}
+	return true
+}
+
+func (r *ringBuffer[E]) insert(idx int, e E) {
+	r.offer(idx, e)
+}
+
+func (r *ringBuffer[E]) Capacity() int {
+	return r.capacity
+}
+
+func (r *ringBuffer[E]) IsFull() bool {
+	return r.size == r.capacity
+}
+
+func (r *ringBuffer[E]) Offer(e E) bool {
+	return r.offer(r.size, e)
+}
+
+func (r *ringBuffer[E]) Snapshot() Sequence[E] {
+	return NewSequence(r.ToSlice()...)
+}
+
+// AddFirst inserts the given element at the front of this buffer.
+// If this buffer is full, the element is the oldest one, so it is evicted immediately or rejected.
+func (r *ringBuffer[E]) AddFirst(e E) {
+	r.offer(0, e)
+}
+
+func (r *ringBuffer[E]) AddLast(e E) {
+	r.offer(r.size, e)
+}
+
+func (r *ringBuffer[E]) Add(elements ...E) {
+	for _, e := range elements {
+		r.offer(r.size, e)
+	}
+}
+
+func (r *ringBuffer[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](r.Iterator()))
+}
+
+func (r *ringBuffer[E]) Iterator() Iterator[E] {
+	return newListIterator[E](r)
+}
+
+func (r *ringBuffer[E]) ListIterator() ListIterator[E] {
+	return newListIterator[E](r)
+}
+
+func (r *ringBuffer[E]) MutableIterator() MutableIterator[E] {
+	return newListIterator[E](r)
+}
+
+var _ json.Unmarshaler = (*ringBuffer[int])(nil)
+
+// UnmarshalJSON replaces the elements of this buffer with the decoded JSON array, applying the overflow policy.
+func (r *ringBuffer[E]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONInto[E](r, data)
+}
+
+var (
+	_ encoding.BinaryUnmarshaler = (*ringBuffer[int])(nil)
+	_ gob.GobDecoder             = (*ringBuffer[int])(nil)
+)
+
+// UnmarshalBinary replaces the elements of this buffer with the decoded binary form, applying the overflow policy.
+func (r *ringBuffer[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryInto[E](r, data)
+}
+
+// GobDecode decodes this buffer by UnmarshalBinary.
+func (r *ringBuffer[E]) GobDecode(data []byte) error {
+	return r.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*ringBuffer[int])(nil)
+	_ fmt.Stringer   = (*ringBuffer[int])(nil)
+	_ slog.LogValuer = (*ringBuffer[int])(nil)
+)
+
+func (r *ringBuffer[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "RingBuffer", r.ToSlice())
+}
+
+func (r *ringBuffer[E]) String() string {
+	return fmt.Sprint(r)
+}
+
+func (r *ringBuffer[E]) LogValue() slog.Value {
+	return logCollection("RingBuffer", r.ToSlice())
+}
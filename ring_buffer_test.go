@@ -0,0 +1,108 @@
+package kol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRingBuffer(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRingBuffer[int](0, OverflowOverwrite)
+	})
+}
+
+func TestRingBuffer_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffer   RingBuffer[int]
+		elements []int
+		want     []int
+	}{
+		{
+			name:     "not full",
+			buffer:   NewRingBuffer[int](3, OverflowOverwrite),
+			elements: []int{1, 2},
+			want:     []int{1, 2},
+		},
+		{
+			name:     "overwrite the oldest",
+			buffer:   NewRingBuffer[int](3, OverflowOverwrite),
+			elements: []int{1, 2, 3, 4, 5},
+			want:     []int{3, 4, 5},
+		},
+		{
+			name:     "reject new elements",
+			buffer:   NewRingBuffer[int](3, OverflowReject),
+			elements: []int{1, 2, 3, 4, 5},
+			want:     []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.buffer.Add(tt.elements...)
+			assert.Equal(t, tt.want, tt.buffer.ToSlice())
+		})
+	}
+}
+
+func TestRingBuffer_Offer(t *testing.T) {
+	r := NewRingBuffer[int](2, OverflowReject)
+	assert.True(t, r.Offer(1))
+	assert.True(t, r.Offer(2))
+	assert.True(t, r.IsFull())
+	assert.False(t, r.Offer(3))
+	assert.Equal(t, 2, r.Capacity())
+
+	r.RemoveFirst()
+	assert.False(t, r.IsFull())
+	assert.True(t, r.Offer(3))
+	assert.Equal(t, []int{2, 3}, r.ToSlice())
+}
+
+func TestNewRingBufferFunc(t *testing.T) {
+	evicted := make([]int, 0)
+	r := NewRingBufferFunc(3, func(e int) {
+		evicted = append(evicted, e)
+	})
+	for i := range 6 {
+		r.Add(i)
+	}
+	assert.Equal(t, []int{0, 1, 2}, evicted)
+	assert.Equal(t, []int{3, 4, 5}, r.ToSlice())
+
+	r.AddFirst(-1)
+	assert.Equal(t, []int{0, 1, 2, -1}, evicted)
+	assert.Equal(t, []int{3, 4, 5}, r.ToSlice())
+}
+
+func TestRingBuffer_List(t *testing.T) {
+	r := NewRingBuffer[int](4, OverflowOverwrite)
+	r.Add(1, 2, 3, 4, 5, 6)
+
+	e, ok := r.ElementAt(0)
+	assert.Equal(t, 3, e)
+	assert.True(t, ok)
+	_, ok = r.ElementAt(4)
+	assert.False(t, ok)
+	assert.Equal(t, 2, r.IndexOf(5))
+	assert.Equal(t, NewList(6, 5, 4, 3), r.Reversed())
+	assert.Equal(t, []int{4, 6}, r.Snapshot().Filter(func(e int) bool { return e%2 == 0 }).ToSlice())
+}
+
+func TestRingBuffer_ListIterator(t *testing.T) {
+	r := NewRingBuffer[int](3, OverflowOverwrite)
+	r.Add(1, 2, 3)
+
+	iter := r.ListIterator()
+	iter.Next()
+	iter.Next()
+	iter.Add(10)
+	assert.Equal(t, []int{2, 10, 3}, r.ToSlice())
+
+	e, ok := iter.Next()
+	assert.Equal(t, 3, e)
+	assert.True(t, ok)
+	assert.False(t, iter.HasNext())
+	assert.NoError(t, iter.Err())
+}
@@ -0,0 +1,51 @@
+package kol
+
+import (
+	"net/netip"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScalar(t *testing.T) {
+	type celsius float32
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{name: "bool", input: "t", want: true},
+		{name: "int", input: "-42", want: int16(-42)},
+		{name: "uint", input: "42", want: uint8(42)},
+		{name: "float", input: "36.6", want: celsius(36.6)},
+		{name: "string", input: " a b ", want: " a b "},
+		{name: "text unmarshaler", input: "127.0.0.1", want: netip.MustParseAddr("127.0.0.1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.New(reflect.TypeOf(tt.want)).Elem()
+			assert.NoError(t, parseScalar(tt.input, v))
+			assert.Equal(t, tt.want, v.Interface())
+		})
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		var p *int
+		assert.NoError(t, parseScalar("7", reflect.ValueOf(&p).Elem()))
+		assert.Equal(t, 7, *p)
+	})
+}
+
+func TestParseScalar_Error(t *testing.T) {
+	var i8 int8
+	assert.ErrorIs(t, parseScalar("128", reflect.ValueOf(&i8).Elem()), strconv.ErrRange)
+	var b bool
+	assert.ErrorIs(t, parseScalar("yes", reflect.ValueOf(&b).Elem()), strconv.ErrSyntax)
+	var p *uint
+	assert.Error(t, parseScalar("-1", reflect.ValueOf(&p).Elem()))
+	assert.Nil(t, p)
+	var c complex64
+	assert.EqualError(t, parseScalar("1", reflect.ValueOf(&c).Elem()), "kol: unsupported element type complex64")
+}
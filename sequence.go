@@ -1,6 +1,13 @@
 package kol
 
-import "fmt"
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
 
 // Sequence returns lazily evaluated values.
 type Sequence[E comparable] interface {
@@ -14,10 +21,28 @@ type Sequence[E comparable] interface {
 	Take(n int) Sequence[E]
 	// Drop returns a sequence containing all elements except first n elements.
 	Drop(n int) Sequence[E]
+	// Err returns the first error that stopped the evaluation of this sequence, or nil.
+	// A sequence derived from a collection stops with ErrConcurrentModification
+	// if the collection is modified during the evaluation.
+	Err() error
+	// JoinToString evaluates elements and returns a string of them formatted by fmt.Sprint, separated by separator
+	// and enclosed by prefix and postfix. If limit is not negative, only the first limit elements are evaluated,
+	// followed by truncated if there are more elements.
+	JoinToString(separator, prefix, postfix string, limit int, truncated string) string
+	// LogValue implements slog.LogValuer, and returns a group of the type and the pipeline of this sequence
+	// without evaluating it.
+	LogValue() slog.Value
 	// ToList evaluate each element and returns it as a List.
 	ToList() List[E]
 	// ToSlice evaluate each element and returns it as a slice.
 	ToSlice() []E
+	// WriteCSV evaluates each element and writes it to w as a CSV record converted by encodeRow.
+	// It returns the first error of encodeRow, writing or this sequence,
+	// after writing the records preceding the error like WriteNDJSON.
+	WriteCSV(w io.Writer, encodeRow func(element E) ([]string, error)) error
+	// WriteNDJSON evaluates each element and writes it to w as a line of JSON.
+	// It returns the first error of encoding, writing or this sequence.
+	WriteNDJSON(w io.Writer) error
 }
 
 type sequence[E comparable] struct {
@@ -54,6 +79,31 @@ func (s *sequence[E]) Drop(n int) Sequence[E] {
 	return newSequence[E](newDropSequence[E](s.seq, n))
 }
 
+func (s *sequence[E]) Err() error {
+	return s.seq.Err()
+}
+
+func (s *sequence[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for n := 0; ; n++ {
+		e, ok := s.seq.Next()
+		if !ok {
+			break
+		}
+		if n > 0 {
+			b.WriteString(separator)
+		}
+		if limit >= 0 && n == limit {
+			b.WriteString(truncated)
+			break
+		}
+		fmt.Fprint(&b, e)
+	}
+	b.WriteString(postfix)
+	return b.String()
+}
+
 func (s *sequence[E]) ToSlice() []E {
 	res := make([]E, 0)
 	for {
@@ -70,15 +120,90 @@ func (s *sequence[E]) ToList() List[E] {
 	return NewList[E](s.ToSlice()...)
 }
 
+func (s *sequence[E]) WriteCSV(w io.Writer, encodeRow func(element E) ([]string, error)) error {
+	cw := csv.NewWriter(w)
+	err := s.writeCSV(cw, encodeRow)
+	cw.Flush()
+	if err != nil {
+		return err
+	}
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return s.seq.Err()
+}
+
+func (s *sequence[E]) writeCSV(cw *csv.Writer, encodeRow func(element E) ([]string, error)) error {
+	for n := 1; ; n++ {
+		e, ok := s.seq.Next()
+		if !ok {
+			return nil
+		}
+		record, err := encodeRow(e)
+		if err != nil {
+			return fmt.Errorf("kol: record %d: %w", n, err)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *sequence[E]) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for n := 1; ; n++ {
+		e, ok := s.seq.Next()
+		if !ok {
+			break
+		}
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("kol: record %d: %w", n, err)
+		}
+	}
+	return s.seq.Err()
+}
+
 var _ fmt.Stringer = (*sequence[int])(nil)
 
 func (s *sequence[E]) String() string {
 	return s.seq.String()
 }
 
+var _ slog.LogValuer = (*sequence[int])(nil)
+
+func (s *sequence[E]) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("type", typeName[E]("Sequence")),
+		slog.String("pipeline", s.String()),
+	)
+}
+
 type seq[E comparable] interface {
 	fmt.Stringer
 	Next() (E, bool)
+	Err() error
+}
+
+type iteratorSequence[E comparable] struct {
+	iter Iterator[E]
+}
+
+var _ seq[int] = (*iteratorSequence[int])(nil)
+
+func newIteratorSequence[E comparable](iter Iterator[E]) seq[E] {
+	return &iteratorSequence[E]{iter: iter}
+}
+
+func (s *iteratorSequence[E]) Next() (E, bool) {
+	return s.iter.Next()
+}
+
+func (s *iteratorSequence[E]) Err() error {
+	return s.iter.Err()
+}
+
+func (s *iteratorSequence[E]) String() string {
+	return fmt.Sprint(s.iter)
 }
 
 type distinctSequence[E comparable] struct {
@@ -108,6 +233,10 @@ func (s *distinctSequence[E]) Next() (E, bool) {
 	return zero, false
 }
 
+func (s *distinctSequence[E]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *distinctSequence[E]) String() string {
 	return fmt.Sprintf("%s > distinct", s.parent)
 }
@@ -137,6 +266,10 @@ func (s *filterSequence[E]) Next() (E, bool) {
 	return zero, false
 }
 
+func (s *filterSequence[E]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *filterSequence[E]) String() string {
 	return fmt.Sprintf("%s > filter", s.parent)
 }
@@ -164,6 +297,10 @@ func (s *mapSequence[E]) Next() (E, bool) {
 	return zero, false
 }
 
+func (s *mapSequence[E]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *mapSequence[E]) String() string {
 	return fmt.Sprintf("%s > map", s.parent)
 }
@@ -195,6 +332,10 @@ func (s *takeSequence[E]) Next() (E, bool) {
 	return e, true
 }
 
+func (s *takeSequence[E]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *takeSequence[E]) String() string {
 	return fmt.Sprintf("%s > take %d", s.parent, s.limit)
 }
@@ -227,6 +368,10 @@ func (s *dropSequence[E]) Next() (E, bool) {
 	return zero, false
 }
 
+func (s *dropSequence[E]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *dropSequence[E]) String() string {
 	return fmt.Sprintf("%s > drop %d", s.parent, s.limit)
 }
@@ -259,6 +404,49 @@ func (s *mapSequenceWithTypeConversion[E1, E2]) Next() (E2, bool) {
 	return zero, false
 }
 
+func (s *mapSequenceWithTypeConversion[E1, E2]) Err() error {
+	return s.parent.Err()
+}
+
 func (s *mapSequenceWithTypeConversion[E1, E2]) String() string {
 	return fmt.Sprintf("%s > map", s.parent)
 }
+
+// CartesianProduct returns a sequence that lazily generates all pairs of an element of first and an element of second,
+// in the iteration order of first and then second.
+func CartesianProduct[A comparable, B comparable](first Iterable[A], second Iterable[B]) Sequence[Pair[A, B]] {
+	return newSequence[Pair[A, B]](newCartesianProductSequence(first.ToSlice(), second.ToSlice()))
+}
+
+type cartesianProductSequence[A comparable, B comparable] struct {
+	first  []A
+	second []B
+	i, j   int
+}
+
+var _ seq[Pair[int, string]] = (*cartesianProductSequence[int, string])(nil)
+
+func newCartesianProductSequence[A comparable, B comparable](first []A, second []B) seq[Pair[A, B]] {
+	return &cartesianProductSequence[A, B]{first: first, second: second}
+}
+
+func (s *cartesianProductSequence[A, B]) Next() (Pair[A, B], bool) {
+	if s.i >= len(s.first) || len(s.second) == 0 {
+		return Pair[A, B]{}, false
+	}
+	p := NewPair(s.first[s.i], s.second[s.j])
+	s.j++
+	if s.j == len(s.second) {
+		s.i++
+		s.j = 0
+	}
+	return p, true
+}
+
+func (s *cartesianProductSequence[A, B]) Err() error {
+	return nil
+}
+
+func (s *cartesianProductSequence[A, B]) String() string {
+	return fmt.Sprintf("cartesian product: %v x %v", s.first, s.second)
+}
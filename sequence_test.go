@@ -205,3 +205,68 @@ func TestMapSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestCartesianProduct(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  Iterable[string]
+		second Iterable[int]
+		want   []Pair[string, int]
+	}{
+		{
+			name:   "pairs in order",
+			first:  NewList("a", "b"),
+			second: NewList(1, 2),
+			want:   []Pair[string, int]{NewPair("a", 1), NewPair("a", 2), NewPair("b", 1), NewPair("b", 2)},
+		},
+		{
+			name:   "empty first",
+			first:  NewList[string](),
+			second: NewList(1, 2),
+			want:   []Pair[string, int]{},
+		},
+		{
+			name:   "empty second",
+			first:  NewList("a"),
+			second: NewSet[int](),
+			want:   []Pair[string, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CartesianProduct(tt.first, tt.second).ToSlice())
+		})
+	}
+}
+
+func TestSequence_JoinToString(t *testing.T) {
+	tests := []struct {
+		name      string
+		elements  []int
+		limit     int
+		truncated string
+		want      string
+	}{
+		{name: "no limit", elements: []int{1, 2, 3}, limit: -1, want: "<1, 2, 3>"},
+		{name: "empty", elements: []int{}, limit: -1, want: "<>"},
+		{name: "truncated", elements: []int{1, 2, 3}, limit: 2, truncated: "...", want: "<1, 2, ...>"},
+		{name: "limit equals size", elements: []int{1, 2}, limit: 2, truncated: "...", want: "<1, 2>"},
+		{name: "zero limit", elements: []int{1}, limit: 0, truncated: "...", want: "<...>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSequence(tt.elements...).JoinToString(", ", "<", ">", tt.limit, tt.truncated)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSequence_JoinToStringLazy(t *testing.T) {
+	evaluated := 0
+	s := NewSequence(1, 2, 3, 4).Map(func(e int) int {
+		evaluated++
+		return e
+	})
+	assert.Equal(t, "1-2-…", s.JoinToString("-", "", "", 2, "…"))
+	assert.Equal(t, 3, evaluated)
+}
@@ -1,16 +1,34 @@
 package kol
 
 import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
 	"golang.org/x/exp/maps"
 )
 
 // Set is an un-ordered collection of elements without duplicate elements.
 type Set[E comparable] interface {
 	Collection[E]
+
+	// Equals returns `true` if the specified set contains the same elements, ignoring their order.
+	// Convert a collection of another kind by ToSet to compare.
+	Equals(other Set[E]) bool
+	// Hash returns a hash of the elements, which is equal for equal sets regardless of the iteration order.
+	// It is stable during the lifetime of the process, but differs between processes.
+	Hash() uint64
+	// JoinToString returns a string of the elements formatted by fmt.Sprint in arbitrary order, separated by separator
+	// and enclosed by prefix and postfix. If limit is not negative, only the first limit elements are rendered,
+	// followed by truncated if there are more elements.
+	JoinToString(separator, prefix, postfix string, limit int, truncated string) string
 }
 
 type set[E comparable] struct {
-	m map[E]struct{}
+	m   map[E]struct{}
+	mod *modCount
 }
 
 func NewSet[E comparable](elements ...E) Set[E] {
@@ -25,29 +43,61 @@ func newSet[E comparable](m map[E]struct{}) Set[E] {
 	return &set[E]{m: m}
 }
 
+// distinctOf returns a map holding distinct elements of the given collection, which must not be modified.
+func distinctOf[E comparable](c Iterable[E]) map[E]struct{} {
+	if s, ok := c.(*set[E]); ok {
+		return s.m
+	}
+	m := make(map[E]struct{})
+	for _, e := range c.ToSlice() {
+		m[e] = struct{}{}
+	}
+	return m
+}
+
 func (s *set[E]) clone() Set[E] {
 	return newSet(maps.Clone(s.m))
 }
 
+func (s *set[E]) modification() *modCount {
+	if s.mod == nil {
+		s.mod = &modCount{}
+	}
+	return s.mod
+}
+
 var _ Collection[int] = (*set[int])(nil)
 
 func (s *set[E]) Add(elements ...E) {
 	for _, e := range elements {
-		s.m[e] = struct{}{}
+		if _, ok := s.m[e]; !ok {
+			s.m[e] = struct{}{}
+			s.mod.increment()
+		}
 	}
 }
 
 func (s *set[E]) Clear() {
 	maps.Clear(s.m)
+	s.mod.increment()
 }
 
 func (s *set[E]) IsEmpty() bool {
 	return s.Size() == 0
 }
 
+func (s *set[E]) MutableIterator() MutableIterator[E] {
+	return newSnapshotIterator(maps.Keys(s.m), func(e E) {
+		s.Remove(e)
+	}, s.modification())
+}
+
 func (s *set[E]) Remove(targets ...E) {
 	for _, t := range targets {
-		delete(s.m, t)
+		if _, ok := s.m[t]; ok {
+			delete(s.m, t)
+			s.mod.increment()
+		}
 	}
 }
 
@@ -56,6 +106,7 @@ func (s *set[E]) Retain(targets ...E) {
 	for e := range s.m {
 		if !tl.Contains(e) {
 			delete(s.m, e)
+			s.mod.increment()
 		}
 	}
 }
@@ -87,11 +138,33 @@ func (s *set[E]) Any(p func(e E) bool) bool {
 	return false
 }
 
+func (s *set[E]) AsSequence() Sequence[E] {
+	return newSequence[E](newIteratorSequence[E](s.Iterator()))
+}
+
 func (s *set[E]) Contains(e E) bool {
 	_, ok := s.m[e]
 	return ok
 }
 
+func (s *set[E]) ContainsAll(elements ...E) bool {
+	for _, e := range elements {
+		if _, ok := s.m[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *set[E]) ContainsAny(elements ...E) bool {
+	for _, e := range elements {
+		if _, ok := s.m[e]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *set[E]) Count(p func(e E) bool) int {
 	count := 0
 	for e := range s.m {
@@ -106,6 +179,19 @@ func (s *set[E]) Distinct() Collection[E] {
 	return s
 }
 
+func (s *set[E]) Equals(other Set[E]) bool {
+	o := distinctOf[E](other)
+	if len(s.m) != len(o) {
+		return false
+	}
+	for e := range o {
+		if _, ok := s.m[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *set[E]) Find(p func(e E) bool) (E, bool) {
 	for e := range s.m {
 		if p(e) {
@@ -127,19 +213,67 @@ func (s *set[E]) Filter(p func(e E) bool) Collection[E] {
 }
 
 func (s *set[E]) ForEach(a func(e E)) {
+	mod := s.modification()
+	expected := mod.count()
 	for e := range s.m {
 		a(e)
+		if err := mod.check(expected); err != nil {
+			panic(err)
+		}
 	}
 }
 
+func (s *set[E]) Hash() uint64 {
+	return hashUnordered(maps.Keys(s.m))
+}
+
 func (s *set[E]) Intersect(other Iterable[E]) Set[E] {
-	res := other.ToSet()
+	res := make(map[E]struct{})
+	for e := range distinctOf(other) {
+		if _, ok := s.m[e]; ok {
+			res[e] = struct{}{}
+		}
+	}
+	return newSet(res)
+}
+
+func (s *set[E]) IsDisjointWith(other Iterable[E]) bool {
+	for e := range distinctOf(other) {
+		if _, ok := s.m[e]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *set[E]) IsSubsetOf(other Iterable[E]) bool {
+	o := distinctOf(other)
+	if len(s.m) > len(o) {
+		return false
+	}
 	for e := range s.m {
-		if _, ok := res.(*set[E]).m[e]; !ok {
-			res.Remove(e)
+		if _, ok := o[e]; !ok {
+			return false
 		}
 	}
-	return res
+	return true
+}
+
+func (s *set[E]) IsSupersetOf(other Iterable[E]) bool {
+	for e := range distinctOf(other) {
+		if _, ok := s.m[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *set[E]) Iterator() Iterator[E] {
+	return s.MutableIterator()
+}
+
+func (s *set[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return s.AsSequence().JoinToString(separator, prefix, postfix, limit, truncated)
 }
 
 func (s *set[E]) Map(t func(e E) E) Collection[E] {
@@ -197,6 +331,18 @@ func (s *set[E]) Subtract(other Iterable[E]) Set[E] {
 	return res
 }
 
+func (s *set[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	res := maps.Clone(s.m)
+	for e := range distinctOf(other) {
+		if _, ok := s.m[e]; ok {
+			delete(res, e)
+		} else {
+			res[e] = struct{}{}
+		}
+	}
+	return newSet(res)
+}
+
 func (s *set[E]) ToList() List[E] {
 	return NewList(maps.Keys(s.m)...)
 }
@@ -210,7 +356,9 @@ func (s *set[E]) ToSlice() []E {
 }
 
 func (s *set[E]) Union(other Iterable[E]) Set[E] {
-	return s.clone().Plus(other.ToSlice()...)
+	res := s.clone()
+	res.Add(other.ToSlice()...)
+	return res
 }
 
 func MapSet[E1 comparable, E2 comparable](collection Collection[E1], transform func(E1) E2) Set[E2] {
@@ -222,3 +370,129 @@ func MapSet[E1 comparable, E2 comparable](collection Collection[E1], transform f
 
 	return NewSet(result...)
 }
+
+// PowerSet returns a sequence that lazily generates all subsets of the given set, starting from the empty set.
+// The set has 2^n subsets for n elements, so take only as many as needed from a large set.
+func PowerSet[E comparable](s Set[E]) Sequence[Set[E]] {
+	return newSequence[Set[E]](newPowerSetSequence(s.ToSlice()))
+}
+
+type powerSetSequence[E comparable] struct {
+	elements []E
+	included []bool // binary counter choosing elements of the next subset
+	done     bool
+}
+
+var _ seq[Set[int]] = (*powerSetSequence[int])(nil)
+
+func newPowerSetSequence[E comparable](elements []E) seq[Set[E]] {
+	return &powerSetSequence[E]{elements: elements, included: make([]bool, len(elements))}
+}
+
+func (s *powerSetSequence[E]) Next() (Set[E], bool) {
+	if s.done {
+		return nil, false
+	}
+	subset := make(map[E]struct{})
+	for i, ok := range s.included {
+		if ok {
+			subset[s.elements[i]] = struct{}{}
+		}
+	}
+	s.done = true
+	for i := range s.included {
+		s.included[i] = !s.included[i]
+		if s.included[i] {
+			s.done = false
+			break
+		}
+	}
+	return newSet(subset), true
+}
+
+func (s *powerSetSequence[E]) Err() error {
+	return nil
+}
+
+func (s *powerSetSequence[E]) String() string {
+	return fmt.Sprintf("power set: %v", s.elements)
+}
+
+// replaceAll replaces the elements of this set with the given elements.
+func (s *set[E]) replaceAll(elements []E) error {
+	m := make(map[E]struct{}, len(elements))
+	for _, e := range elements {
+		m[e] = struct{}{}
+	}
+	s.m = m
+	s.mod.increment()
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*set[int])(nil)
+	_ json.Unmarshaler = (*set[int])(nil)
+)
+
+// MarshalJSON encodes this set as a JSON array in arbitrary order. Use SortedJSONSet for a deterministic order.
+func (s *set[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONElements(maps.Keys(s.m))
+}
+
+// UnmarshalJSON replaces the elements of this set with the distinct elements of the decoded JSON array.
+func (s *set[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*set[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*set[int])(nil)
+	_ gob.GobEncoder             = (*set[int])(nil)
+	_ gob.GobDecoder             = (*set[int])(nil)
+)
+
+// MarshalBinary encodes this set into a compact binary form. The elements are encoded in arbitrary order.
+func (s *set[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(maps.Keys(s.m))
+}
+
+// UnmarshalBinary replaces the elements of this set with the distinct elements of the decoded binary form.
+func (s *set[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return s.replaceAll(elements)
+}
+
+// GobEncode encodes this set by MarshalBinary.
+func (s *set[E]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode decodes this set by UnmarshalBinary.
+func (s *set[E]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*set[int])(nil)
+	_ fmt.Stringer   = (*set[int])(nil)
+	_ slog.LogValuer = (*set[int])(nil)
+)
+
+func (s *set[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "Set", sortedForDisplay(s.ToSlice()))
+}
+
+func (s *set[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *set[E]) LogValue() slog.Value {
+	return logCollection("Set", sortedForDisplay(s.ToSlice()))
+}
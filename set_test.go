@@ -1,6 +1,7 @@
 package kol
 
 import (
+	"slices"
 	"strconv"
 	"testing"
 
@@ -466,6 +467,246 @@ func TestSet_Single(t *testing.T) {
 	}
 }
 
+func TestSet_Intersect(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   Set[int]
+		other Iterable[int]
+		want  Set[int]
+	}{
+		{
+			name:  "common elements",
+			set:   NewSet(1, 2, 3),
+			other: NewList(2, 3, 4, 3),
+			want:  NewSet(2, 3),
+		},
+		{
+			name:  "other set is not returned",
+			set:   NewSet(1),
+			other: NewSet(1, 2),
+			want:  NewSet(1),
+		},
+		{
+			name:  "no common element",
+			set:   NewSet(1, 2),
+			other: NewSet(3, 4),
+			want:  NewSet[int](),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.set.Intersect(tt.other))
+		})
+	}
+}
+
+func TestSet_SymmetricDifference(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   Set[int]
+		other Iterable[int]
+		want  Set[int]
+	}{
+		{
+			name:  "overlapping",
+			set:   NewSet(1, 2, 3),
+			other: NewList(3, 4, 4),
+			want:  NewSet(1, 2, 4),
+		},
+		{
+			name:  "equal",
+			set:   NewSet(1, 2),
+			other: NewSet(2, 1),
+			want:  NewSet[int](),
+		},
+		{
+			name:  "empty other",
+			set:   NewSet(1, 2),
+			other: NewList[int](),
+			want:  NewSet(1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.set.SymmetricDifference(tt.other))
+		})
+	}
+}
+
+func TestSet_Relations(t *testing.T) {
+	tests := []struct {
+		name         string
+		set          Set[string]
+		other        Iterable[string]
+		wantSubset   bool
+		wantSuperset bool
+		wantDisjoint bool
+		wantEquals   bool
+	}{
+		{
+			name:         "subset",
+			set:          NewSet("read"),
+			other:        NewList("read", "write"),
+			wantSubset:   true,
+			wantSuperset: false,
+			wantDisjoint: false,
+			wantEquals:   false,
+		},
+		{
+			name:         "superset",
+			set:          NewSet("read", "write", "admin"),
+			other:        NewSet("read", "write"),
+			wantSubset:   false,
+			wantSuperset: true,
+			wantDisjoint: false,
+			wantEquals:   false,
+		},
+		{
+			name:         "equal ignoring duplicates",
+			set:          NewSet("read", "write"),
+			other:        NewList("write", "read", "write"),
+			wantSubset:   true,
+			wantSuperset: true,
+			wantDisjoint: false,
+			wantEquals:   true,
+		},
+		{
+			name:         "disjoint",
+			set:          NewSet("read"),
+			other:        NewSet("write"),
+			wantSubset:   false,
+			wantSuperset: false,
+			wantDisjoint: true,
+			wantEquals:   false,
+		},
+		{
+			name:         "empty",
+			set:          NewSet[string](),
+			other:        NewList[string](),
+			wantSubset:   true,
+			wantSuperset: true,
+			wantDisjoint: true,
+			wantEquals:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSubset, tt.set.IsSubsetOf(tt.other))
+			assert.Equal(t, tt.wantSuperset, tt.set.IsSupersetOf(tt.other))
+			assert.Equal(t, tt.wantDisjoint, tt.set.IsDisjointWith(tt.other))
+			assert.Equal(t, tt.wantEquals, tt.set.Equals(tt.other.ToSet()))
+		})
+	}
+}
+
+func TestSet_ContainsAllAndAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []int
+		wantAll  bool
+		wantAny  bool
+	}{
+		{
+			name:     "all contained",
+			elements: []int{1, 3},
+			wantAll:  true,
+			wantAny:  true,
+		},
+		{
+			name:     "some contained",
+			elements: []int{1, 4},
+			wantAll:  false,
+			wantAny:  true,
+		},
+		{
+			name:     "none contained",
+			elements: []int{4, 5},
+			wantAll:  false,
+			wantAny:  false,
+		},
+		{
+			name:     "no elements",
+			elements: []int{},
+			wantAll:  true,
+			wantAny:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSet(1, 2, 3)
+			assert.Equal(t, tt.wantAll, s.ContainsAll(tt.elements...))
+			assert.Equal(t, tt.wantAny, s.ContainsAny(tt.elements...))
+		})
+	}
+}
+
+func TestSet_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   Set[int]
+		other Set[int]
+		want  bool
+	}{
+		{name: "same elements", set: NewSet(1, 2, 3), other: NewSet(3, 2, 1), want: true},
+		{name: "different elements", set: NewSet(1, 2), other: NewSet(1, 3), want: false},
+		{name: "subset", set: NewSet(1, 2), other: NewSet(1), want: false},
+		{name: "other set implementation", set: NewSet(1, 2), other: NewConcurrentSet(2, 1), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.set.Equals(tt.other))
+			assert.Equal(t, tt.want, tt.other.Equals(tt.set))
+		})
+	}
+}
+
+func TestEquals_Symmetric(t *testing.T) {
+	l := NewList(1, 2, 3)
+	s := NewSet(3, 2, 1)
+	assert.False(t, l.Equals(s))
+	assert.True(t, s.Equals(l.ToSet()))
+
+	_, ok := any(l).(Set[int])
+	assert.False(t, ok, "a List must not be usable as a Set")
+}
+
+func TestSet_Hash(t *testing.T) {
+	assert.Equal(t, NewSet("a", "b", "c").Hash(), NewSet("c", "b", "a").Hash())
+	assert.Equal(t, NewSet("a", "b").Hash(), NewConcurrentSet("b", "a").Hash())
+	assert.Equal(t, NewSet("a", "b").Hash(), SynchronizedSet(NewSet("b", "a")).Hash())
+	assert.NotEqual(t, NewSet("a", "b").Hash(), NewSet("a", "c").Hash())
+}
+
+func TestPowerSet(t *testing.T) {
+	t.Run("all subsets", func(t *testing.T) {
+		subsets := PowerSet(NewSet(1, 2, 3)).ToSlice()
+		assert.Len(t, subsets, 8)
+		assert.True(t, subsets[0].IsEmpty())
+		got := make([][]int, 0, len(subsets))
+		for _, s := range subsets {
+			elements := s.ToSlice()
+			slices.Sort(elements)
+			got = append(got, elements)
+		}
+		assert.ElementsMatch(t, [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, got)
+	})
+
+	t.Run("empty set", func(t *testing.T) {
+		subsets := PowerSet(NewSet[int]()).ToSlice()
+		assert.Len(t, subsets, 1)
+		assert.True(t, subsets[0].IsEmpty())
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		elements := make([]int, 100)
+		for i := range elements {
+			elements[i] = i
+		}
+		subsets := PowerSet(NewSet(elements...)).Take(3).ToSlice()
+		assert.Len(t, subsets, 3)
+	})
+}
+
 func TestMapSet(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -486,3 +727,12 @@ func TestMapSet(t *testing.T) {
 		})
 	}
 }
+
+func TestSet_JoinToString(t *testing.T) {
+	assert.Equal(t, "{1}", NewSet(1, 1).JoinToString(", ", "{", "}", -1, ""))
+	assert.Contains(t, []string{"1,2", "2,1"}, NewSet(1, 2).JoinToString(",", "", "", -1, ""))
+	assert.Regexp(t, `^[12],\.\.\.$`, NewSet(1, 2).JoinToString(",", "", "", 1, "..."))
+	assert.Equal(t, "{}", NewConcurrentSet[int]().JoinToString(", ", "{", "}", -1, ""))
+	assert.Equal(t, "1", SynchronizedSet(NewSet(1)).JoinToString(",", "", "", -1, ""))
+	assert.Equal(t, "1", NewExpiringSet(0, nil, 1).JoinToString(",", "", "", -1, ""))
+}
@@ -0,0 +1,441 @@
+package kol
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrMalformedPGArray is returned when scanning a malformed Postgres array literal.
+	ErrMalformedPGArray = errors.New("kol: malformed array literal")
+	// ErrNullElement is returned when scanning a Postgres array containing NULL into non-pointer elements.
+	ErrNullElement = errors.New("kol: NULL element in array")
+)
+
+var (
+	_ driver.Valuer = JSONList[int]{}
+	_ sql.Scanner   = (*JSONList[int])(nil)
+)
+
+// Value encodes the list as a JSON array, or NULL if it is nil.
+func (l JSONList[E]) Value() (driver.Value, error) {
+	if l.List == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l.List)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan replaces the list with a new List decoded from a JSON array.
+func (l *JSONList[E]) Scan(src any) error {
+	data, err := scanText(src, l)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		l.List = nil
+		return nil
+	}
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	l.List = NewList(elements...)
+	return nil
+}
+
+var (
+	_ driver.Valuer = JSONSet[int]{}
+	_ sql.Scanner   = (*JSONSet[int])(nil)
+)
+
+// Value encodes the set as a JSON array in arbitrary order, or NULL if it is nil.
+func (s JSONSet[E]) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(s.Set)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan replaces the set with a new Set decoded from a JSON array.
+func (s *JSONSet[E]) Scan(src any) error {
+	data, err := scanText(src, s)
+	if err != nil {
+		return err
+	}
+	return scanJSONSet(&s.Set, data)
+}
+
+// scanJSONSet replaces the given set with a new Set decoded from the given JSON array, or nil if data is nil.
+func scanJSONSet[E comparable](s *Set[E], data []byte) error {
+	if data == nil {
+		*s = nil
+		return nil
+	}
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	*s = NewSet(elements...)
+	return nil
+}
+
+var (
+	_ driver.Valuer = SortedJSONSet[int]{}
+	_ sql.Scanner   = (*SortedJSONSet[int])(nil)
+)
+
+// Value encodes the set as a JSON array in sorted order, or NULL if it is nil.
+func (s SortedJSONSet[E]) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, nil
+	}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan replaces the set with a new Set decoded from a JSON array.
+func (s *SortedJSONSet[E]) Scan(src any) error {
+	data, err := scanText(src, s)
+	if err != nil {
+		return err
+	}
+	return scanJSONSet(&s.Set, data)
+}
+
+// PGArrayList is a List stored in a SQL column as a Postgres array literal such as `{a,"b c",NULL}`.
+// A nil List is stored as NULL, and NULL is scanned as a nil List.
+//
+// Elements may be booleans, numbers, strings, implementations of encoding.TextMarshaler and encoding.TextUnmarshaler,
+// or pointers to them, in which case nil pointers are stored as NULL elements.
+// Multi-dimensional arrays are not supported.
+type PGArrayList[E comparable] struct {
+	List[E]
+}
+
+var (
+	_ driver.Valuer = PGArrayList[int]{}
+	_ sql.Scanner   = (*PGArrayList[int])(nil)
+)
+
+// Value formats the list as a Postgres array literal.
+func (l PGArrayList[E]) Value() (driver.Value, error) {
+	if l.List == nil {
+		return nil, nil
+	}
+	return formatPGArray(l.ToSlice())
+}
+
+// Scan replaces the list with a new List parsed from a Postgres array literal.
+func (l *PGArrayList[E]) Scan(src any) error {
+	data, err := scanText(src, l)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		l.List = nil
+		return nil
+	}
+	elements, err := parsePGArray[E](string(data))
+	if err != nil {
+		return err
+	}
+	l.List = NewList(elements...)
+	return nil
+}
+
+var (
+	_ fmt.Formatter  = PGArrayList[int]{}
+	_ fmt.Stringer   = PGArrayList[int]{}
+	_ slog.LogValuer = PGArrayList[int]{}
+)
+
+// Format implements fmt.Formatter like List, and formats a nil List as an empty one.
+func (l PGArrayList[E]) Format(f fmt.State, verb rune) {
+	formatWrapped(f, verb, "List", l.List)
+}
+
+func (l PGArrayList[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+// LogValue implements slog.LogValuer like List, and logs a nil List as an empty one.
+func (l PGArrayList[E]) LogValue() slog.Value {
+	return logWrapped("List", l.List)
+}
+
+// PGArraySet is a Set stored in a SQL column as a Postgres array literal such as `{a,"b c"}`.
+// A nil Set is stored as NULL, and NULL is scanned as a nil Set.
+// The supported elements are the same as PGArrayList.
+type PGArraySet[E comparable] struct {
+	Set[E]
+}
+
+var (
+	_ driver.Valuer = PGArraySet[int]{}
+	_ sql.Scanner   = (*PGArraySet[int])(nil)
+)
+
+// Value formats the set as a Postgres array literal. The elements are formatted in arbitrary order.
+func (s PGArraySet[E]) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, nil
+	}
+	return formatPGArray(s.ToSlice())
+}
+
+// Scan replaces the set with a new Set parsed from a Postgres array literal.
+func (s *PGArraySet[E]) Scan(src any) error {
+	data, err := scanText(src, s)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		s.Set = nil
+		return nil
+	}
+	elements, err := parsePGArray[E](string(data))
+	if err != nil {
+		return err
+	}
+	s.Set = NewSet(elements...)
+	return nil
+}
+
+var (
+	_ fmt.Formatter  = PGArraySet[int]{}
+	_ fmt.Stringer   = PGArraySet[int]{}
+	_ slog.LogValuer = PGArraySet[int]{}
+)
+
+// Format implements fmt.Formatter like Set, and formats a nil Set as an empty one.
+func (s PGArraySet[E]) Format(f fmt.State, verb rune) {
+	formatWrapped(f, verb, "Set", s.Set)
+}
+
+func (s PGArraySet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// LogValue implements slog.LogValuer like Set, and logs a nil Set as an empty one.
+func (s PGArraySet[E]) LogValue() slog.Value {
+	return logWrapped("Set", s.Set)
+}
+
+// scanText returns the text of the given source value, or nil if it is NULL.
+func scanText(src any, dst any) ([]byte, error) {
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(src), nil
+	case []byte:
+		return src, nil
+	default:
+		return nil, fmt.Errorf("kol: cannot scan %T into %T", src, dst)
+	}
+}
+
+// formatPGArray formats the given elements as a Postgres array literal.
+func formatPGArray[E comparable](elements []E) (string, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := range elements {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := formatPGElement(&b, reflect.ValueOf(&elements[i]).Elem()); err != nil {
+			return "", err
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+func formatPGElement(b *strings.Builder, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			b.WriteString("NULL")
+			return nil
+		}
+		v = v.Elem()
+	}
+	if m, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return err
+		}
+		b.WriteString(quotePGElement(string(text)))
+		return nil
+	}
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		if v.Bool() {
+			b.WriteByte('t')
+		} else {
+			b.WriteByte('f')
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		switch f := v.Float(); {
+		case math.IsInf(f, 1):
+			b.WriteString("Infinity")
+		case math.IsInf(f, -1):
+			b.WriteString("-Infinity")
+		default:
+			b.WriteString(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+		}
+	case reflect.String:
+		b.WriteString(quotePGElement(v.String()))
+	default:
+		return fmt.Errorf("kol: unsupported array element type %s", v.Type())
+	}
+	return nil
+}
+
+// quotePGElement quotes the given text if it would be ambiguous as an unquoted element.
+func quotePGElement(s string) string {
+	if s != "" && !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{},\"\\ \t\n\r\v\f") {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// pgToken is an element of a Postgres array literal.
+type pgToken struct {
+	text string
+	null bool
+}
+
+// parsePGArray parses the given Postgres array literal.
+func parsePGArray[E comparable](s string) ([]E, error) {
+	tokens, err := tokenizePGArray(s)
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]E, len(tokens))
+	for i, t := range tokens {
+		if err := parsePGElement(t, reflect.ValueOf(&elements[i]).Elem()); err != nil {
+			return nil, fmt.Errorf("kol: array element %d: %w", i, err)
+		}
+	}
+	return elements, nil
+}
+
+func tokenizePGArray(s string) ([]pgToken, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, ErrMalformedPGArray
+	}
+	s = s[1 : len(s)-1]
+	if strings.TrimSpace(s) == "" {
+		return []pgToken{}, nil
+	}
+
+	var tokens []pgToken
+	for i := 0; ; {
+		for i < len(s) && isPGSpace(s[i]) {
+			i++
+		}
+		var t pgToken
+		switch {
+		case i < len(s) && s[i] == '{':
+			return nil, fmt.Errorf("%w: multi-dimensional arrays are not supported", ErrMalformedPGArray)
+		case i < len(s) && s[i] == '"':
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(s) {
+					return nil, ErrMalformedPGArray
+				}
+				if s[i] == '"' {
+					i++
+					break
+				}
+				if s[i] == '\\' {
+					if i++; i >= len(s) {
+						return nil, ErrMalformedPGArray
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			for i < len(s) && isPGSpace(s[i]) {
+				i++
+			}
+			t.text = b.String()
+		default:
+			var b strings.Builder
+			for ; i < len(s) && s[i] != ','; i++ {
+				switch s[i] {
+				case '"', '{', '}':
+					return nil, ErrMalformedPGArray
+				case '\\':
+					if i++; i >= len(s) {
+						return nil, ErrMalformedPGArray
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			t.text = strings.TrimRight(b.String(), " \t\n\r\v\f")
+			if t.text == "" {
+				return nil, ErrMalformedPGArray
+			}
+			t.null = strings.EqualFold(t.text, "NULL")
+		}
+		tokens = append(tokens, t)
+
+		if i >= len(s) {
+			return tokens, nil
+		}
+		if s[i] != ',' {
+			return nil, ErrMalformedPGArray
+		}
+		i++
+	}
+}
+
+func isPGSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// parsePGElement parses the given token of a Postgres array literal into v by parseScalar.
+// NULL is parsed as a nil pointer, or ErrNullElement for other types.
+func parsePGElement(t pgToken, v reflect.Value) error {
+	if t.null {
+		if v.Kind() == reflect.Pointer {
+			return nil
+		}
+		return ErrNullElement
+	}
+	return parseScalar(t.text, v)
+}
@@ -0,0 +1,209 @@
+package kol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConnector connects to a fake database that stores the arguments of the last Exec,
+// and returns them as a single row from any Query.
+type fakeConnector struct {
+	values []driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{c: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{c: c}
+}
+
+type fakeDriver struct {
+	c *fakeConnector
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return d.c.Connect(context.Background())
+}
+
+type fakeConn struct {
+	c *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{c: c.c}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	c *fakeConnector
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.values = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: s.c.values}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return make([]string, len(r.values))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func TestSQL_RoundTrip(t *testing.T) {
+	c := &fakeConnector{}
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	_, err := db.Exec("INSERT",
+		JSONList[string]{NewList("a", "b")},
+		JSONSet[int]{NewSet(1)},
+		PGArrayList[string]{NewList("x", "y z", "NULL")},
+		PGArraySet[int]{NewSet(7)},
+		PGArrayList[int]{},
+		SortedJSONSet[int]{NewSet(3, 1, 2)},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{`["a","b"]`, `[1]`, `{x,"y z","NULL"}`, `{7}`, nil, `[1,2,3]`}, c.values)
+
+	var (
+		jl   JSONList[string]
+		js   JSONSet[int]
+		pl   PGArrayList[string]
+		ps   PGArraySet[int]
+		null = PGArrayList[int]{NewList(9)}
+		ss   SortedJSONSet[int]
+	)
+	assert.NoError(t, db.QueryRow("SELECT").Scan(&jl, &js, &pl, &ps, &null, &ss))
+	assert.Equal(t, []string{"a", "b"}, jl.ToSlice())
+	assert.Equal(t, []int{1}, js.ToSlice())
+	assert.Equal(t, []string{"x", "y z", "NULL"}, pl.ToSlice())
+	assert.Equal(t, []int{7}, ps.ToSlice())
+	assert.Nil(t, null.List)
+	assert.ElementsMatch(t, []int{1, 2, 3}, ss.ToSlice())
+}
+
+func TestSQL_ScanBytes(t *testing.T) {
+	var s PGArraySet[string]
+	assert.NoError(t, s.Scan([]byte(`{b,a,b}`)))
+	assert.ElementsMatch(t, []string{"a", "b"}, s.ToSlice())
+
+	var l JSONList[int]
+	assert.NoError(t, l.Scan(`null`))
+	assert.Equal(t, []int{}, l.ToSlice())
+
+	assert.EqualError(t, l.Scan(1), "kol: cannot scan int into *kol.JSONList[int]")
+	assert.Error(t, l.Scan(`{1}`))
+
+	ss := SortedJSONSet[int]{NewSet(1)}
+	assert.NoError(t, ss.Scan(nil))
+	assert.Nil(t, ss.Set)
+	assert.EqualError(t, ss.Scan(1), "kol: cannot scan int into *kol.SortedJSONSet[int]")
+}
+
+func TestPGArrayList_Format(t *testing.T) {
+	assert.Equal(t, "[2, 1]", fmt.Sprint(PGArrayList[int]{NewList(2, 1)}))
+	assert.Equal(t, "[]", fmt.Sprint(PGArrayList[int]{}))
+	assert.Equal(t, "List[int] size=0 []", fmt.Sprintf("%+v", PGArrayList[int]{}))
+	assert.Equal(t, "[1, 2]", PGArraySet[int]{NewSet(2, 1)}.String())
+	assert.Equal(t, "[]", PGArraySet[string]{}.String())
+	assert.Equal(t, "Set[string] size=0 []", fmt.Sprintf("%+v", PGArraySet[string]{}))
+	assert.Equal(t, "[type=List[int] size=0 sample=[]]", PGArrayList[int]{}.LogValue().String())
+	assert.Equal(t, "[type=Set[int] size=0 sample=[]]", PGArraySet[int]{}.LogValue().String())
+}
+
+func TestFormatPGArray(t *testing.T) {
+	s := "s"
+	assertPGArray(t, `{}`, []int{})
+	assertPGArray(t, `{1,-2}`, []int{1, -2})
+	assertPGArray(t, `{t,f}`, []bool{true, false})
+	assertPGArray(t, `{1.5,Infinity,-Infinity}`, []float64{1.5, math.Inf(1), math.Inf(-1)})
+	assertPGArray(t, `{"","a b","a,b","\"q\"","back\\slash","{}","null",日本}`,
+		[]string{"", "a b", "a,b", `"q"`, `back\slash`, "{}", "null", "日本"})
+	assertPGArray(t, `{s,NULL}`, []*string{&s, nil})
+	assertPGArray(t, `{10.0.0.1,::1}`, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")})
+
+	_, err := formatPGArray([]Pair[int, int]{NewPair(1, 2)})
+	assert.Error(t, err)
+}
+
+func assertPGArray[E comparable](t *testing.T, want string, elements []E) {
+	t.Helper()
+	got, err := formatPGArray(elements)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	parsed, err := parsePGArray[E](got)
+	assert.NoError(t, err)
+	assert.Equal(t, elements, parsed)
+}
+
+func TestParsePGArray(t *testing.T) {
+	got, err := parsePGArray[string](` { a , "b c" ,d\,e, "" } `)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b c", "d,e", ""}, got)
+
+	ptrs, err := parsePGArray[*int](`{1,NULL,null}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *ptrs[0])
+	assert.Nil(t, ptrs[1])
+	assert.Nil(t, ptrs[2])
+
+	bools, err := parsePGArray[bool](`{t,false,TRUE}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, bools)
+}
+
+func TestParsePGArray_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{name: "no braces", input: `1,2`, want: ErrMalformedPGArray},
+		{name: "empty element", input: `{1,,2}`, want: ErrMalformedPGArray},
+		{name: "trailing comma", input: `{1,}`, want: ErrMalformedPGArray},
+		{name: "unterminated quote", input: `{"a}`, want: ErrMalformedPGArray},
+		{name: "junk after quote", input: `{"a"b}`, want: ErrMalformedPGArray},
+		{name: "multi-dimensional", input: `{{1},{2}}`, want: ErrMalformedPGArray},
+		{name: "null", input: `{1,NULL}`, want: ErrNullElement},
+		{name: "overflow", input: `{128}`, want: strconv.ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePGArray[int8](tt.input)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package kol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSkipRecord is returned by a decodeRow function of SequenceFromCSV to skip the record, e.g. a header.
+var ErrSkipRecord = errors.New("kol: skip record")
+
+// LineError is an error decoding a line of the input of a sequence.
+type LineError struct {
+	// Line is the 1-based line number.
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("kol: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// SequenceFromNDJSON returns a sequence that lazily decodes each line of the given reader as a JSON value.
+// Blank lines are skipped. The sequence stops at the first error, which Err returns as a *LineError for a decode error.
+func SequenceFromNDJSON[E comparable](r io.Reader) Sequence[E] {
+	return newSequence[E](&ndjsonSequence[E]{r: bufio.NewReader(r)})
+}
+
+type ndjsonSequence[E comparable] struct {
+	r    *bufio.Reader
+	line int
+	done bool
+	err  error
+}
+
+var _ seq[int] = (*ndjsonSequence[int])(nil)
+
+func (s *ndjsonSequence[E]) Next() (E, bool) {
+	var zero E
+	for !s.done {
+		b, err := s.r.ReadBytes('\n')
+		if err != nil {
+			s.done = true
+			if !errors.Is(err, io.EOF) {
+				s.err = err
+				return zero, false
+			}
+		}
+		if len(b) == 0 {
+			continue
+		}
+		s.line++
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+		var e E
+		if err := json.Unmarshal(b, &e); err != nil {
+			s.done = true
+			s.err = &LineError{Line: s.line, Err: err}
+			return zero, false
+		}
+		return e, true
+	}
+	return zero, false
+}
+
+func (s *ndjsonSequence[E]) Err() error {
+	return s.err
+}
+
+func (s *ndjsonSequence[E]) String() string {
+	return "ndjson"
+}
+
+// SequenceFromCSV returns a sequence that lazily reads each record of the given reader as CSV
+// and converts it into an element by decodeRow, which may return ErrSkipRecord to skip the record.
+// The sequence stops at the first error. Err returns a *csv.ParseError for malformed CSV,
+// or a *LineError wrapping the error returned by decodeRow.
+func SequenceFromCSV[E comparable](r io.Reader, decodeRow func(record []string) (E, error)) Sequence[E] {
+	return newSequence[E](&csvSequence[E]{r: csv.NewReader(r), decodeRow: decodeRow})
+}
+
+type csvSequence[E comparable] struct {
+	r         *csv.Reader
+	decodeRow func(record []string) (E, error)
+	err       error
+}
+
+var _ seq[int] = (*csvSequence[int])(nil)
+
+func (s *csvSequence[E]) Next() (E, bool) {
+	var zero E
+	for s.err == nil {
+		record, err := s.r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.err = err
+			break
+		}
+		e, err := s.decodeRow(record)
+		if errors.Is(err, ErrSkipRecord) {
+			continue
+		}
+		if err != nil {
+			line, _ := s.r.FieldPos(0)
+			s.err = &LineError{Line: line, Err: err}
+			break
+		}
+		return e, true
+	}
+	return zero, false
+}
+
+func (s *csvSequence[E]) Err() error {
+	return s.err
+}
+
+func (s *csvSequence[E]) String() string {
+	return "csv"
+}
@@ -0,0 +1,147 @@
+package kol
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func TestSequenceFromNDJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     []event
+		wantLine int
+	}{
+		{
+			name:  "lines",
+			input: "{\"id\":1,\"kind\":\"a\"}\n\n  \n{\"id\":2,\"kind\":\"b\"}",
+			want:  []event{{ID: 1, Kind: "a"}, {ID: 2, Kind: "b"}},
+		},
+		{
+			name:  "trailing newline",
+			input: "{\"id\":1}\r\n",
+			want:  []event{{ID: 1}},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  []event{},
+		},
+		{
+			name:     "decode error",
+			input:    "{\"id\":1}\n\n{\"id\":\"x\"}\n{\"id\":3}\n",
+			want:     []event{{ID: 1}},
+			wantLine: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SequenceFromNDJSON[event](strings.NewReader(tt.input))
+			assert.Equal(t, tt.want, s.ToSlice())
+			if tt.wantLine == 0 {
+				assert.NoError(t, s.Err())
+				return
+			}
+			var lineErr *LineError
+			assert.ErrorAs(t, s.Err(), &lineErr)
+			assert.Equal(t, tt.wantLine, lineErr.Line)
+		})
+	}
+}
+
+func TestSequenceFromNDJSON_Lazy(t *testing.T) {
+	s := SequenceFromNDJSON[int](iotest.OneByteReader(strings.NewReader("1\n2\n3\nx\n"))).
+		Filter(func(e int) bool { return e%2 == 1 }).
+		Take(1)
+	assert.Equal(t, []int{1}, s.ToSlice())
+	assert.NoError(t, s.Err())
+	assert.Equal(t, "ndjson > filter > take 1", s.(*sequence[int]).String())
+}
+
+func TestSequenceFromNDJSON_ReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	s := SequenceFromNDJSON[int](iotest.ErrReader(errRead))
+	assert.Empty(t, s.ToSlice())
+	assert.ErrorIs(t, s.Err(), errRead)
+}
+
+func decodeEvent(record []string) (event, error) {
+	if record[0] == "id" {
+		return event{}, ErrSkipRecord
+	}
+	id, err := strconv.Atoi(record[0])
+	return event{ID: id, Kind: record[1]}, err
+}
+
+func TestSequenceFromCSV(t *testing.T) {
+	s := SequenceFromCSV(strings.NewReader("id,kind\n1,a\n2,\"b\nc\"\n"), decodeEvent)
+	assert.Equal(t, []event{{ID: 1, Kind: "a"}, {ID: 2, Kind: "b\nc"}}, s.ToSlice())
+	assert.NoError(t, s.Err())
+
+	s = SequenceFromCSV(strings.NewReader("id,kind\n1,a\n\"2\nx\",b\n"), decodeEvent)
+	assert.Equal(t, []event{{ID: 1, Kind: "a"}}, s.ToSlice())
+	var lineErr *LineError
+	assert.ErrorAs(t, s.Err(), &lineErr)
+	assert.Equal(t, 3, lineErr.Line)
+	assert.ErrorIs(t, s.Err(), strconv.ErrSyntax)
+
+	s = SequenceFromCSV(strings.NewReader("1,a\n2\n"), decodeEvent)
+	assert.Equal(t, []event{{ID: 1, Kind: "a"}}, s.ToSlice())
+	var parseErr *csv.ParseError
+	assert.ErrorAs(t, s.Err(), &parseErr)
+	assert.Equal(t, 2, parseErr.Line)
+}
+
+func TestSequence_WriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewSequence(event{ID: 1, Kind: "a"}, event{ID: 2, Kind: "b"}).WriteNDJSON(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"id\":1,\"kind\":\"a\"}\n{\"id\":2,\"kind\":\"b\"}\n", buf.String())
+
+	got := SequenceFromNDJSON[event](&buf).ToSlice()
+	assert.Equal(t, []event{{ID: 1, Kind: "a"}, {ID: 2, Kind: "b"}}, got)
+
+	buf.Reset()
+	err = SequenceFromNDJSON[int](strings.NewReader("1\nx\n")).WriteNDJSON(&buf)
+	assert.Equal(t, "1\n", buf.String())
+	var lineErr *LineError
+	assert.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 2, lineErr.Line)
+}
+
+func TestSequence_WriteCSV(t *testing.T) {
+	encode := func(e event) ([]string, error) {
+		if e.ID < 0 {
+			return nil, errors.New("negative id")
+		}
+		return []string{strconv.Itoa(e.ID), e.Kind}, nil
+	}
+
+	var buf bytes.Buffer
+	err := NewSequence(event{ID: 1, Kind: "a,b"}, event{ID: 2, Kind: "c"}).WriteCSV(&buf, encode)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,\"a,b\"\n2,c\n", buf.String())
+
+	buf.Reset()
+	err = NewSequence(event{ID: 1, Kind: "a"}, event{ID: -1}).WriteCSV(&buf, encode)
+	assert.EqualError(t, err, "kol: record 2: negative id")
+	assert.Equal(t, "1,a\n", buf.String())
+
+	buf.Reset()
+	err = SequenceFromNDJSON[event](strings.NewReader("{\"id\":1}\nx\n")).WriteCSV(&buf, encode)
+	var lineErr *LineError
+	assert.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, "1,\n", buf.String())
+}
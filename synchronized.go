@@ -0,0 +1,579 @@
+package kol
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// SynchronizedList returns a ConcurrentList backed by the given list and guarded by a sync.RWMutex.
+// The given list must not be accessed directly afterwards.
+//
+// Methods taking a function evaluate it against a snapshot of the list outside the lock,
+// so the function may access the returned list. ComputeIfAbsent is an exception:
+// it evaluates its functions under the lock to be atomic, so they must not access the returned list,
+// or they deadlock. Iterators hold the lock for each call only,
+// so they stop with ErrConcurrentModification if another goroutine modifies the list during the iteration;
+// iterate over Snapshot instead to avoid it.
+func SynchronizedList[E comparable](l List[E]) ConcurrentList[E] {
+	return &synchronizedList[E]{
+		synchronizedCollection: synchronizedCollection[E]{
+			inner: l,
+			copyInner: func() Collection[E] {
+				return l.ToList()
+			},
+		},
+		list: l,
+	}
+}
+
+// SynchronizedSet returns a ConcurrentSet backed by the given set and guarded by a sync.RWMutex.
+// The given set must not be accessed directly afterwards.
+//
+// Methods taking a function evaluate it against a snapshot of the set outside the lock,
+// so the function may access the returned set. ComputeIfAbsent is an exception:
+// it evaluates its functions under the lock to be atomic, so they must not access the returned set,
+// or they deadlock.
+func SynchronizedSet[E comparable](s Set[E]) ConcurrentSet[E] {
+	return &synchronizedSet[E]{
+		synchronizedCollection: synchronizedCollection[E]{
+			inner: s,
+			copyInner: func() Collection[E] {
+				return s.ToSet()
+			},
+		},
+	}
+}
+
+type synchronizedCollection[E comparable] struct {
+	mu        sync.RWMutex
+	inner     Collection[E]
+	copyInner func() Collection[E]
+}
+
+func (c *synchronizedCollection[E]) snapshot() Collection[E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.copyInner()
+}
+
+func (c *synchronizedCollection[E]) Add(elements ...E) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Add(elements...)
+}
+
+func (c *synchronizedCollection[E]) AddIfAbsent(e E) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inner.Contains(e) {
+		return false
+	}
+	c.inner.Add(e)
+	return true
+}
+
+func (c *synchronizedCollection[E]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Clear()
+}
+
+// ComputeIfAbsent evaluates p and compute under the write lock, unlike the other methods taking a function.
+func (c *synchronizedCollection[E]) ComputeIfAbsent(p func(e E) bool, compute func() E) E {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.inner.Find(p); ok {
+		return e
+	}
+	e := compute()
+	c.inner.Add(e)
+	return e
+}
+
+func (c *synchronizedCollection[E]) IsEmpty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.IsEmpty()
+}
+
+func (c *synchronizedCollection[E]) MutableIterator() MutableIterator[E] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &synchronizedIterator[E]{mu: &c.mu, inner: c.inner.MutableIterator()}
+}
+
+func (c *synchronizedCollection[E]) Remove(targets ...E) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Remove(targets...)
+}
+
+func (c *synchronizedCollection[E]) Retain(targets ...E) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Retain(targets...)
+}
+
+func (c *synchronizedCollection[E]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.Size()
+}
+
+func (c *synchronizedCollection[E]) All(p func(e E) bool) bool {
+	return c.snapshot().All(p)
+}
+
+func (c *synchronizedCollection[E]) Any(p func(e E) bool) bool {
+	return c.snapshot().Any(p)
+}
+
+func (c *synchronizedCollection[E]) AsSequence() Sequence[E] {
+	return c.snapshot().AsSequence()
+}
+
+func (c *synchronizedCollection[E]) Contains(e E) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.Contains(e)
+}
+
+func (c *synchronizedCollection[E]) ContainsAll(elements ...E) bool {
+	return c.snapshot().ContainsAll(elements...)
+}
+
+func (c *synchronizedCollection[E]) ContainsAny(elements ...E) bool {
+	return c.snapshot().ContainsAny(elements...)
+}
+
+func (c *synchronizedCollection[E]) Count(p func(e E) bool) int {
+	return c.snapshot().Count(p)
+}
+
+func (c *synchronizedCollection[E]) Distinct() Collection[E] {
+	return c.snapshot().Distinct()
+}
+
+func (c *synchronizedCollection[E]) Filter(p func(e E) bool) Collection[E] {
+	return c.snapshot().Filter(p)
+}
+
+func (c *synchronizedCollection[E]) Find(p func(e E) bool) (E, bool) {
+	return c.snapshot().Find(p)
+}
+
+func (c *synchronizedCollection[E]) ForEach(a func(e E)) {
+	c.snapshot().ForEach(a)
+}
+
+func (c *synchronizedCollection[E]) Intersect(other Iterable[E]) Set[E] {
+	return c.snapshot().Intersect(other)
+}
+
+func (c *synchronizedCollection[E]) IsDisjointWith(other Iterable[E]) bool {
+	return c.snapshot().IsDisjointWith(other)
+}
+
+func (c *synchronizedCollection[E]) IsSubsetOf(other Iterable[E]) bool {
+	return c.snapshot().IsSubsetOf(other)
+}
+
+func (c *synchronizedCollection[E]) IsSupersetOf(other Iterable[E]) bool {
+	return c.snapshot().IsSupersetOf(other)
+}
+
+func (c *synchronizedCollection[E]) Iterator() Iterator[E] {
+	return c.MutableIterator()
+}
+
+func (c *synchronizedCollection[E]) Map(t func(e E) E) Collection[E] {
+	return c.snapshot().Map(t)
+}
+
+func (c *synchronizedCollection[E]) Minus(e ...E) Collection[E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.Minus(e...)
+}
+
+func (c *synchronizedCollection[E]) None(p func(e E) bool) bool {
+	return c.snapshot().None(p)
+}
+
+func (c *synchronizedCollection[E]) Plus(e ...E) Collection[E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.Plus(e...)
+}
+
+func (c *synchronizedCollection[E]) Single(p func(e E) bool) (E, bool) {
+	return c.snapshot().Single(p)
+}
+
+func (c *synchronizedCollection[E]) Subtract(other Iterable[E]) Set[E] {
+	return c.snapshot().Subtract(other)
+}
+
+func (c *synchronizedCollection[E]) SymmetricDifference(other Iterable[E]) Set[E] {
+	return c.snapshot().SymmetricDifference(other)
+}
+
+func (c *synchronizedCollection[E]) ToList() List[E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.ToList()
+}
+
+func (c *synchronizedCollection[E]) ToSet() Set[E] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.ToSet()
+}
+
+func (c *synchronizedCollection[E]) ToSlice() []E {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inner.ToSlice()
+}
+
+func (c *synchronizedCollection[E]) Union(other Iterable[E]) Set[E] {
+	return c.snapshot().Union(other)
+}
+
+type synchronizedList[E comparable] struct {
+	synchronizedCollection[E]
+	list List[E]
+}
+
+var _ ConcurrentList[int] = (*synchronizedList[int])(nil)
+
+func (l *synchronizedList[E]) Snapshot() List[E] {
+	return l.snapshotList()
+}
+
+func (l *synchronizedList[E]) snapshotList() List[E] {
+	return l.snapshot().(List[E]) //nolint:forcetypeassert
+}
+
+func (l *synchronizedList[E]) Drop(n uint) Collection[E] {
+	return l.snapshotList().Drop(n)
+}
+
+func (l *synchronizedList[E]) DropWhile(p func(e E) bool) Collection[E] {
+	return l.snapshotList().DropWhile(p)
+}
+
+func (l *synchronizedList[E]) ElementAt(idx int) (E, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.ElementAt(idx)
+}
+
+func (l *synchronizedList[E]) ElementAtOrElse(idx int, f func() E) E {
+	e, ok := l.ElementAt(idx)
+	if !ok {
+		return f()
+	}
+	return e
+}
+
+func (l *synchronizedList[E]) FilterIndexed(p func(idx int, e E) bool) Collection[E] {
+	return l.snapshotList().FilterIndexed(p)
+}
+
+func (l *synchronizedList[E]) FindLast(p func(e E) bool) (E, bool) {
+	return l.snapshotList().FindLast(p)
+}
+
+func (l *synchronizedList[E]) ForEachIndexed(a func(idx int, e E)) {
+	l.snapshotList().ForEachIndexed(a)
+}
+
+func (l *synchronizedList[E]) Equals(other Iterable[E]) bool {
+	return l.snapshotList().Equals(other)
+}
+
+func (l *synchronizedList[E]) Hash() uint64 {
+	return l.snapshotList().Hash()
+}
+
+func (l *synchronizedList[E]) IndexOf(e E) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.IndexOf(e)
+}
+
+func (l *synchronizedList[E]) IndexOfFirst(p func(e E) bool) int {
+	return l.snapshotList().IndexOfFirst(p)
+}
+
+func (l *synchronizedList[E]) IndexOfLast(p func(e E) bool) int {
+	return l.snapshotList().IndexOfLast(p)
+}
+
+func (l *synchronizedList[E]) IntersectOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.snapshotList().IntersectOrdered(other, semantics)
+}
+
+func (l *synchronizedList[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return l.snapshotList().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+func (l *synchronizedList[E]) ListIterator() ListIterator[E] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &synchronizedListIterator[E]{mu: &l.mu, inner: l.list.ListIterator()}
+}
+
+func (l *synchronizedList[E]) MapIndexed(t func(idx int, e E) E) Collection[E] {
+	return l.snapshotList().MapIndexed(t)
+}
+
+func (l *synchronizedList[E]) Partition(p func(e E) bool) (List[E], List[E]) {
+	return l.snapshotList().Partition(p)
+}
+
+func (l *synchronizedList[E]) Reversed() List[E] {
+	return l.snapshotList().Reversed()
+}
+
+func (l *synchronizedList[E]) Shuffled() List[E] {
+	return l.snapshotList().Shuffled()
+}
+
+func (l *synchronizedList[E]) SubtractOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.snapshotList().SubtractOrdered(other, semantics)
+}
+
+func (l *synchronizedList[E]) Take(n uint) Collection[E] {
+	return l.snapshotList().Take(n)
+}
+
+func (l *synchronizedList[E]) TakeWhile(p func(e E) bool) Collection[E] {
+	return l.snapshotList().TakeWhile(p)
+}
+
+func (l *synchronizedList[E]) UnionOrdered(other Iterable[E], semantics Semantics) List[E] {
+	return l.snapshotList().UnionOrdered(other, semantics)
+}
+
+type synchronizedSet[E comparable] struct {
+	synchronizedCollection[E]
+}
+
+func (s *synchronizedSet[E]) Equals(other Set[E]) bool {
+	return s.Snapshot().Equals(other)
+}
+
+func (s *synchronizedSet[E]) Hash() uint64 {
+	return s.snapshot().(Set[E]).Hash() //nolint:forcetypeassert
+}
+
+func (s *synchronizedSet[E]) JoinToString(separator, prefix, postfix string, limit int, truncated string) string {
+	return s.Snapshot().JoinToString(separator, prefix, postfix, limit, truncated)
+}
+
+var _ ConcurrentSet[int] = (*synchronizedSet[int])(nil)
+
+func (s *synchronizedSet[E]) Snapshot() Set[E] {
+	return s.snapshot().(Set[E]) //nolint:forcetypeassert
+}
+
+// synchronizedIterator holds the lock of the collection for each call of the inner iterator.
+type synchronizedIterator[E comparable] struct {
+	mu    *sync.RWMutex
+	inner MutableIterator[E]
+}
+
+var _ MutableIterator[int] = (*synchronizedIterator[int])(nil)
+
+func (i *synchronizedIterator[E]) HasNext() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.HasNext()
+}
+
+func (i *synchronizedIterator[E]) Next() (E, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Next()
+}
+
+func (i *synchronizedIterator[E]) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Err()
+}
+
+func (i *synchronizedIterator[E]) Remove() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Remove()
+}
+
+// synchronizedListIterator holds the lock of the list for each call of the inner iterator.
+type synchronizedListIterator[E comparable] struct {
+	mu    *sync.RWMutex
+	inner ListIterator[E]
+}
+
+var _ ListIterator[int] = (*synchronizedListIterator[int])(nil)
+
+func (i *synchronizedListIterator[E]) HasNext() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.HasNext()
+}
+
+func (i *synchronizedListIterator[E]) Next() (E, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Next()
+}
+
+func (i *synchronizedListIterator[E]) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Err()
+}
+
+func (i *synchronizedListIterator[E]) Remove() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Remove()
+}
+
+func (i *synchronizedListIterator[E]) HasPrevious() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.HasPrevious()
+}
+
+func (i *synchronizedListIterator[E]) Previous() (E, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Previous()
+}
+
+func (i *synchronizedListIterator[E]) NextIndex() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.NextIndex()
+}
+
+func (i *synchronizedListIterator[E]) PreviousIndex() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.PreviousIndex()
+}
+
+func (i *synchronizedListIterator[E]) Set(e E) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inner.Set(e)
+}
+
+func (i *synchronizedListIterator[E]) Add(e E) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.inner.Add(e)
+}
+
+// replaceAll replaces the elements of this collection with the given elements.
+func (c *synchronizedCollection[E]) replaceAll(elements []E) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Clear()
+	c.inner.Add(elements...)
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*synchronizedCollection[int])(nil)
+	_ json.Unmarshaler = (*synchronizedCollection[int])(nil)
+)
+
+// MarshalJSON encodes a snapshot of the underlying collection as a JSON array.
+func (c *synchronizedCollection[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.snapshot())
+}
+
+// UnmarshalJSON replaces the elements of the underlying collection with the decoded JSON array.
+func (c *synchronizedCollection[E]) UnmarshalJSON(data []byte) error {
+	elements, err := unmarshalJSONElements[E](data)
+	if err != nil {
+		return err
+	}
+	return c.replaceAll(elements)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*synchronizedCollection[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*synchronizedCollection[int])(nil)
+	_ gob.GobEncoder             = (*synchronizedCollection[int])(nil)
+	_ gob.GobDecoder             = (*synchronizedCollection[int])(nil)
+)
+
+// MarshalBinary encodes a snapshot of the underlying collection into a compact binary form.
+func (c *synchronizedCollection[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinaryElements(c.snapshot().ToSlice())
+}
+
+// UnmarshalBinary replaces the elements of the underlying collection with the decoded binary form.
+func (c *synchronizedCollection[E]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+	return c.replaceAll(elements)
+}
+
+// GobEncode encodes this underlying collection by MarshalBinary.
+func (c *synchronizedCollection[E]) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+// GobDecode decodes this underlying collection by UnmarshalBinary.
+func (c *synchronizedCollection[E]) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+var (
+	_ fmt.Formatter  = (*synchronizedList[int])(nil)
+	_ fmt.Stringer   = (*synchronizedList[int])(nil)
+	_ slog.LogValuer = (*synchronizedList[int])(nil)
+)
+
+func (l *synchronizedList[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "SynchronizedList", l.ToSlice())
+}
+
+func (l *synchronizedList[E]) String() string {
+	return fmt.Sprint(l)
+}
+
+func (l *synchronizedList[E]) LogValue() slog.Value {
+	return logCollection("SynchronizedList", l.ToSlice())
+}
+
+var (
+	_ fmt.Formatter  = (*synchronizedSet[int])(nil)
+	_ fmt.Stringer   = (*synchronizedSet[int])(nil)
+	_ slog.LogValuer = (*synchronizedSet[int])(nil)
+)
+
+func (s *synchronizedSet[E]) Format(f fmt.State, verb rune) {
+	formatCollection(f, verb, "SynchronizedSet", sortedForDisplay(s.ToSlice()))
+}
+
+func (s *synchronizedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+func (s *synchronizedSet[E]) LogValue() slog.Value {
+	return logCollection("SynchronizedSet", sortedForDisplay(s.ToSlice()))
+}
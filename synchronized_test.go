@@ -0,0 +1,115 @@
+package kol
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynchronizedList(t *testing.T) {
+	t.Run("concurrent modifications", func(t *testing.T) {
+		l := SynchronizedList(NewList[int]())
+		var wg sync.WaitGroup
+		for i := range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Add(i)
+				l.AddIfAbsent(i)
+				l.Contains(i)
+				l.Filter(func(e int) bool { return e%2 == 0 })
+				l.Snapshot().ForEach(func(_ int) {})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 100, l.Size())
+		for i := range 100 {
+			assert.True(t, l.Contains(i))
+		}
+	})
+
+	t.Run("action may access the list", func(t *testing.T) {
+		l := SynchronizedList(NewList(1, 2, 3))
+		l.ForEach(func(e int) {
+			l.Add(e * 10)
+		})
+		assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, l.ToSlice())
+	})
+
+	t.Run("list operations", func(t *testing.T) {
+		l := SynchronizedList(NewList(1, 2, 3, 4))
+		e, ok := l.ElementAt(1)
+		assert.Equal(t, 2, e)
+		assert.True(t, ok)
+		assert.Equal(t, 2, l.IndexOf(3))
+		assert.Equal(t, NewList(3, 4), l.Drop(2))
+		assert.Equal(t, NewList(1, 2), l.Take(2))
+		assert.Equal(t, NewList(4, 3, 2, 1), l.Reversed())
+	})
+
+	t.Run("list iterator", func(t *testing.T) {
+		l := SynchronizedList(NewList(1, 2, 3))
+		iter := l.ListIterator()
+		for iter.HasNext() {
+			if e, _ := iter.Next(); e == 2 {
+				assert.True(t, iter.Remove())
+			}
+		}
+		assert.Equal(t, []int{1, 3}, l.ToSlice())
+
+		iter = l.ListIterator()
+		iter.Next()
+		l.Add(4)
+		_, ok := iter.Next()
+		assert.False(t, ok)
+		assert.ErrorIs(t, iter.Err(), ErrConcurrentModification)
+	})
+}
+
+func TestSynchronizedSet(t *testing.T) {
+	t.Run("concurrent modifications", func(t *testing.T) {
+		s := SynchronizedSet(NewSet[int]())
+		var wg sync.WaitGroup
+		for i := range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Add(i + 1000)
+				s.Remove(i + 1000)
+				s.AddIfAbsent(i)
+				s.Any(func(e int) bool { return e == i })
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 100, s.Size())
+	})
+
+	t.Run("compute if absent", func(t *testing.T) {
+		s := SynchronizedSet(NewSet[int]())
+		var wg sync.WaitGroup
+		computed := make([]int, 10)
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				computed[i] = s.ComputeIfAbsent(
+					func(e int) bool { return e > 0 },
+					func() int { return i + 1 })
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, s.Size())
+		for _, e := range computed {
+			assert.True(t, s.Contains(e))
+		}
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		s := SynchronizedSet(NewSet(1, 2))
+		snapshot := s.Snapshot()
+		s.Add(3)
+		assert.ElementsMatch(t, []int{1, 2}, snapshot.ToSlice())
+		assert.ElementsMatch(t, []int{1, 2, 3}, s.ToSlice())
+	})
+}
@@ -0,0 +1,369 @@
+package kol
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"golang.org/x/exp/slices"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// TemplateFuncs returns functions for text/template, which cannot call generic functions of this package.
+// Convert it with html/template.FuncMap to use it with html/template.
+//
+// Each function takes a collection as its last argument so that it can be used in pipelines,
+// e.g. `{{range .Issues | filterBy "State" "open" | sortBy "Title" | take 10}}`.
+// A collection is a slice, an array, or a value with a ToSlice method such as collections and sequences of this package.
+// Functions returning a collection return a slice of the same element type.
+// The elements of sets are iterated in an unspecified order, so sort them with sortBy before take or drop.
+//
+// A key selects a value of an element like a template field chain without the leading dot:
+// an exported field, a method without arguments, or a map value, separated by dots as in "Author.Name".
+// An empty key selects the element itself.
+//
+//   - filterBy key value collection: the elements whose key equals value
+//   - sortBy key collection: the elements stably sorted by key
+//   - take n collection: the first n elements
+//   - drop n collection: the elements except the first n elements
+//   - distinct collection: the elements without duplicates, in their first order
+//   - join separator collection: the elements formatted by fmt.Sprint and separated by separator
+//   - groupBy key collection: a map from each key to the elements having it, in their order
+//   - chunk size collection: the elements split into slices of size, the last of which may be shorter
+//   - contains value collection: whether any element equals value
+//
+// Values are equal if they are numbers with the same value, booleans or strings with the same value,
+// or otherwise equal by ==, so that template constants match fields of named or sized types.
+// Invalid arguments, unknown keys and errors of sequences or methods are returned as errors of the template execution.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"filterBy": templateFilterBy,
+		"sortBy":   templateSortBy,
+		"take":     templateTake,
+		"drop":     templateDrop,
+		"distinct": templateDistinct,
+		"join":     templateJoin,
+		"groupBy":  templateGroupBy,
+		"chunk":    templateChunk,
+		"contains": templateContains,
+	}
+}
+
+func templateFilterBy(key string, value, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	filtered := reflect.MakeSlice(elements.Type(), 0, 0)
+	for i := range elements.Len() {
+		v, err := templateKey(elements.Index(i), key)
+		if err != nil {
+			return nil, err
+		}
+		if templateEqual(v, reflect.ValueOf(value)) {
+			filtered = reflect.Append(filtered, elements.Index(i))
+		}
+	}
+	return filtered.Interface(), nil
+}
+
+func templateSortBy(key string, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	type keyed struct {
+		key     any
+		element reflect.Value
+	}
+	keys := make([]keyed, elements.Len())
+	for i := range keys {
+		v, err := templateKey(elements.Index(i), key)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = keyed{key: templateInterface(v), element: elements.Index(i)}
+	}
+	slices.SortStableFunc(keys, func(a, b keyed) int {
+		return compareForDisplay(a.key, b.key)
+	})
+	sorted := reflect.MakeSlice(elements.Type(), len(keys), len(keys))
+	for i, k := range keys {
+		sorted.Index(i).Set(k.element)
+	}
+	return sorted.Interface(), nil
+}
+
+func templateTake(n int, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("kol: negative count %d", n)
+	}
+	return elements.Slice(0, min(n, elements.Len())).Interface(), nil
+}
+
+func templateDrop(n int, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("kol: negative count %d", n)
+	}
+	return elements.Slice(min(n, elements.Len()), elements.Len()).Interface(), nil
+}
+
+func templateDistinct(collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[any]struct{}, elements.Len())
+	distinct := reflect.MakeSlice(elements.Type(), 0, 0)
+	for i := range elements.Len() {
+		e := elements.Index(i)
+		if !e.Comparable() {
+			return nil, fmt.Errorf("kol: uncomparable element %v", e)
+		}
+		if _, ok := seen[e.Interface()]; !ok {
+			seen[e.Interface()] = struct{}{}
+			distinct = reflect.Append(distinct, e)
+		}
+	}
+	return distinct.Interface(), nil
+}
+
+func templateJoin(separator string, collection any) (string, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, elements.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(elements.Index(i).Interface())
+	}
+	return strings.Join(parts, separator), nil
+}
+
+func templateGroupBy(key string, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	groups := reflect.MakeMap(reflect.MapOf(reflect.TypeFor[any](), elements.Type()))
+	for i := range elements.Len() {
+		v, err := templateKey(elements.Index(i), key)
+		if err != nil {
+			return nil, err
+		}
+		k := reflect.New(reflect.TypeFor[any]()).Elem()
+		if v.IsValid() {
+			if !v.Comparable() {
+				return nil, fmt.Errorf("kol: uncomparable key %v", v)
+			}
+			k.Set(v)
+		}
+		group := groups.MapIndex(k)
+		if !group.IsValid() {
+			group = reflect.MakeSlice(elements.Type(), 0, 0)
+		}
+		groups.SetMapIndex(k, reflect.Append(group, elements.Index(i)))
+	}
+	return groups.Interface(), nil
+}
+
+func templateChunk(size int, collection any) (any, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("kol: non-positive chunk size %d", size)
+	}
+	chunks := reflect.MakeSlice(reflect.SliceOf(elements.Type()), 0, (elements.Len()+size-1)/size)
+	for i := 0; i < elements.Len(); i += size {
+		chunks = reflect.Append(chunks, elements.Slice3(i, min(i+size, elements.Len()), min(i+size, elements.Len())))
+	}
+	return chunks.Interface(), nil
+}
+
+func templateContains(value, collection any) (bool, error) {
+	elements, err := templateElements(collection)
+	if err != nil {
+		return false, err
+	}
+	for i := range elements.Len() {
+		if templateEqual(elements.Index(i), reflect.ValueOf(value)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// templateElements returns the elements of the given collection as a slice value.
+func templateElements(collection any) (reflect.Value, error) {
+	v := reflect.ValueOf(collection)
+	if !v.IsValid() {
+		return reflect.Value{}, errors.New("kol: nil collection")
+	}
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Slice:
+		return v, nil
+	case reflect.Array:
+		elements := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(elements, v)
+		return elements, nil
+	}
+
+	toSlice := v.MethodByName("ToSlice")
+	if !toSlice.IsValid() || toSlice.Type().NumIn() != 0 || toSlice.Type().NumOut() != 1 ||
+		toSlice.Type().Out(0).Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("kol: cannot iterate over %T", collection)
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("kol: nil collection %T", collection)
+	}
+	elements := toSlice.Call(nil)[0]
+	if s, ok := collection.(interface{ Err() error }); ok {
+		if err := s.Err(); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return elements, nil
+}
+
+// templateKey returns the value selected by the given key of an element.
+func templateKey(element reflect.Value, key string) (reflect.Value, error) {
+	if key == "" {
+		return element, nil
+	}
+	v := element
+	for _, name := range strings.Split(key, ".") {
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return reflect.Value{}, fmt.Errorf("kol: nil value for key %q", key)
+		}
+
+		if v.Kind() != reflect.Pointer && v.CanAddr() {
+			v = v.Addr()
+		}
+		if m := v.MethodByName(name); m.IsValid() {
+			var err error
+			if v, err = templateCall(m, name); err != nil {
+				return reflect.Value{}, err
+			}
+			continue
+		}
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("kol: nil pointer evaluating %s", name)
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() { //nolint:exhaustive
+		case reflect.Struct:
+			f, ok := v.Type().FieldByName(name)
+			if !ok || !f.IsExported() {
+				return reflect.Value{}, fmt.Errorf("kol: can't evaluate field %s in type %s", name, v.Type())
+			}
+			v = v.FieldByIndex(f.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, fmt.Errorf("kol: can't evaluate key %s in type %s", name, v.Type())
+			}
+			e := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !e.IsValid() {
+				e = reflect.Zero(v.Type().Elem())
+			}
+			v = e
+		default:
+			return reflect.Value{}, fmt.Errorf("kol: can't evaluate field %s in type %s", name, v.Type())
+		}
+	}
+	return v, nil
+}
+
+// templateCall calls a method without arguments, which returns a value and optionally an error.
+func templateCall(m reflect.Value, name string) (reflect.Value, error) {
+	t := m.Type()
+	if t.NumIn() != 0 || t.NumOut() == 0 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		return reflect.Value{}, fmt.Errorf("kol: can't call method %s with type %s", name, t)
+	}
+	out := m.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("kol: error calling %s: %w", name, out[1].Interface().(error))
+	}
+	return out[0], nil
+}
+
+// templateInterface returns the value held by v, or nil if v is nil.
+func templateInterface(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// templateEqual reports whether a equals b, comparing numbers, booleans and strings by their values.
+func templateEqual(a, b reflect.Value) bool {
+	for a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return a.Int() == b.Int()
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return a.Uint() == b.Uint()
+	case isIntKind(a.Kind()) && isUintKind(b.Kind()):
+		return a.Int() >= 0 && uint64(a.Int()) == b.Uint()
+	case isUintKind(a.Kind()) && isIntKind(b.Kind()):
+		return b.Int() >= 0 && a.Uint() == uint64(b.Int())
+	case isNumberKind(a.Kind()) && isNumberKind(b.Kind()):
+		return templateFloat(a) == templateFloat(b)
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return a.Bool() == b.Bool()
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return a.String() == b.String()
+	case a.Type() == b.Type() && a.Comparable():
+		return a.Equal(b)
+	default:
+		return false
+	}
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func templateFloat(v reflect.Value) float64 {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int())
+	case isUintKind(v.Kind()):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
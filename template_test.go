@@ -0,0 +1,214 @@
+package kol
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type templateAuthor struct {
+	Name string
+}
+
+type templateIssue struct {
+	Title    string
+	State    string
+	Priority int64
+	Author   *templateAuthor
+	Labels   map[string]string
+}
+
+func (i templateIssue) Open() bool {
+	return i.State == "open"
+}
+
+func (i *templateIssue) Summary() (string, error) {
+	if i.Title == "" {
+		return "", errors.New("no title")
+	}
+	return i.State + ": " + i.Title, nil
+}
+
+func newTemplateIssues() []templateIssue {
+	return []templateIssue{
+		{Title: "crash", State: "open", Priority: 1, Author: &templateAuthor{Name: "kai"}, Labels: map[string]string{"area": "core"}},
+		{Title: "docs", State: "closed", Priority: 3, Author: &templateAuthor{Name: "mio"}},
+		{Title: "leak", State: "open", Priority: 2, Author: &templateAuthor{Name: "mio"}, Labels: map[string]string{"area": "io"}},
+		{Title: "typo", State: "open", Priority: 3, Author: &templateAuthor{Name: "kai"}},
+	}
+}
+
+func executeTemplate(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("test").Funcs(TemplateFuncs()).Parse(text)
+	assert.NoError(t, err)
+	var out strings.Builder
+	err = tmpl.Execute(&out, data)
+	return out.String(), err
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	issues := newTemplateIssues()
+	tests := []struct {
+		name string
+		text string
+		data any
+		want string
+	}{
+		{
+			name: "filterBy and sortBy on slice",
+			text: `{{range .| filterBy "State" "open" | sortBy "Priority"}}{{.Title}} {{end}}`,
+			data: issues,
+			want: "crash leak typo ",
+		},
+		{
+			name: "filterBy number and method",
+			text: `{{range .| filterBy "Priority" 3 | filterBy "Open" true}}{{.Title}}{{end}}`,
+			data: issues,
+			want: "typo",
+		},
+		{
+			name: "nested and map keys",
+			text: `{{.| filterBy "Author.Name" "mio" | sortBy "Labels.area" | len}} {{range .| sortBy "Labels.area"}}{{index .Labels "area"}},{{end}}`,
+			data: issues,
+			want: "2 ,,core,io,",
+		},
+		{
+			name: "pointer method",
+			text: `{{range . | take 2}}{{.Summary}};{{end}}`,
+			data: issues,
+			want: "open: crash;closed: docs;",
+		},
+		{
+			name: "take and drop",
+			text: `{{. | take 2 | join ","}}|{{. | drop 2 | join ","}}|{{. | take 9 | join ","}}|{{. | drop 9 | join ","}}`,
+			data: NewList(1, 2, 3),
+			want: "1,2|3|1,2,3|",
+		},
+		{
+			name: "distinct and sortBy element",
+			text: `{{. | distinct | join " "}}|{{. | sortBy "" | join " "}}`,
+			data: [5]string{"b", "a", "b", "c", "a"},
+			want: "b a c|a a b b c",
+		},
+		{
+			name: "set",
+			text: `{{. | sortBy "" | join ", "}} {{contains 2 .}} {{contains 4 .}}`,
+			data: NewSet(3, 1, 2),
+			want: "1, 2, 3 true false",
+		},
+		{
+			name: "sequence",
+			text: `{{. | join "-"}}`,
+			data: NewList(1, 2, 3, 4).AsSequence().Filter(func(e int) bool { return e%2 == 0 }),
+			want: "2-4",
+		},
+		{
+			name: "groupBy",
+			text: `{{range $k, $v := . | groupBy "Author.Name"}}{{$k}}={{range $v}}{{.Title}},{{end}} {{end}}`,
+			data: issues,
+			want: "kai=crash,typo, mio=docs,leak, ",
+		},
+		{
+			name: "chunk",
+			text: `{{range . | chunk 2}}[{{join " " .}}]{{end}}`,
+			data: NewFrozenList("a", "b", "c", "d", "e"),
+			want: "[a b][c d][e]",
+		},
+		{
+			name: "empty",
+			text: `{{. | filterBy "" 1 | len}}{{. | chunk 3 | len}}{{. | join ","}}{{contains 1 .}}`,
+			data: []int{},
+			want: "00false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executeTemplate(t, tt.text, tt.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplateFuncs_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		data    any
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			text:    `{{. | sortBy "Owner"}}`,
+			data:    newTemplateIssues(),
+			wantErr: "error calling sortBy: kol: can't evaluate field Owner in type kol.templateIssue",
+		},
+		{
+			name:    "method error",
+			text:    `{{. | filterBy "Summary" ""}}`,
+			data:    []templateIssue{{}},
+			wantErr: "error calling filterBy: kol: error calling Summary: no title",
+		},
+		{
+			name:    "nil pointer",
+			text:    `{{. | groupBy "Author.Name"}}`,
+			data:    []templateIssue{{}},
+			wantErr: "error calling groupBy: kol: nil pointer evaluating Name",
+		},
+		{
+			name:    "not a collection",
+			text:    `{{. | take 1}}`,
+			data:    1,
+			wantErr: "error calling take: kol: cannot iterate over int",
+		},
+		{
+			name:    "nil collection",
+			text:    `{{.Missing | join ","}}`,
+			data:    map[string]any{},
+			wantErr: "error calling join: kol: nil collection",
+		},
+		{
+			name:    "negative count",
+			text:    `{{. | drop -1}}`,
+			data:    []int{1},
+			wantErr: "error calling drop: kol: negative count -1",
+		},
+		{
+			name:    "chunk size",
+			text:    `{{. | chunk 0}}`,
+			data:    []int{1},
+			wantErr: "error calling chunk: kol: non-positive chunk size 0",
+		},
+		{
+			name:    "uncomparable",
+			text:    `{{. | distinct}}`,
+			data:    [][]int{{1}},
+			wantErr: "error calling distinct: kol: uncomparable element [1]",
+		},
+		{
+			name:    "sequence error",
+			text:    `{{. | join ","}}`,
+			data:    SequenceFromNDJSON[int](strings.NewReader("1\nx\n")),
+			wantErr: "error calling join: kol: line 2:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeTemplate(t, tt.text, tt.data)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTemplateFuncs_HTML(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("test").Funcs(htmltemplate.FuncMap(TemplateFuncs())).
+		Parse(`<ul>{{range . | sortBy ""}}<li>{{.}}</li>{{end}}</ul>`))
+	var out strings.Builder
+	assert.NoError(t, tmpl.Execute(&out, NewSet("<b>", "a")))
+	assert.Equal(t, "<ul><li>&lt;b&gt;</li><li>a</li></ul>", out.String())
+}
@@ -0,0 +1,200 @@
+package kol
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lines returns a sequence that lazily reads each line of the given reader, without the trailing "\n" or "\r\n".
+// Unlike bufio.Scanner, lines may be of any length. The sequence stops at the first read error, which Err returns.
+func Lines(r io.Reader) Sequence[string] {
+	return newSequence[string](&linesSequence{r: bufio.NewReader(r)})
+}
+
+type linesSequence struct {
+	r    *bufio.Reader
+	done bool
+	err  error
+}
+
+var _ seq[string] = (*linesSequence)(nil)
+
+func (s *linesSequence) Next() (string, bool) {
+	if s.done {
+		return "", false
+	}
+	line, err := s.r.ReadString('\n')
+	if err != nil {
+		s.done = true
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+			return "", false
+		}
+		if line == "" {
+			return "", false
+		}
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), true
+}
+
+func (s *linesSequence) Err() error {
+	return s.err
+}
+
+func (s *linesSequence) String() string {
+	return "lines"
+}
+
+// SplitSeq returns a sequence that lazily splits s into substrings separated by sep, like strings.Split.
+// If sep is empty, it splits s after each UTF-8 sequence.
+func SplitSeq(s, sep string) Sequence[string] {
+	return newSequence[string](&splitSequence{s: s, sep: sep})
+}
+
+type splitSequence struct {
+	s, sep string
+	done   bool
+}
+
+var _ seq[string] = (*splitSequence)(nil)
+
+func (s *splitSequence) Next() (string, bool) {
+	if s.done {
+		return "", false
+	}
+	if s.sep == "" {
+		if s.s == "" {
+			s.done = true
+			return "", false
+		}
+		_, size := utf8.DecodeRuneInString(s.s)
+		e := s.s[:size]
+		s.s = s.s[size:]
+		return e, true
+	}
+	i := strings.Index(s.s, s.sep)
+	if i < 0 {
+		s.done = true
+		return s.s, true
+	}
+	e := s.s[:i]
+	s.s = s.s[i+len(s.sep):]
+	return e, true
+}
+
+func (s *splitSequence) Err() error {
+	return nil
+}
+
+func (s *splitSequence) String() string {
+	return fmt.Sprintf("split by %q", s.sep)
+}
+
+// Runes returns a sequence that lazily decodes the runes of s.
+// Invalid UTF-8 sequences are decoded as utf8.RuneError.
+func Runes(s string) Sequence[rune] {
+	return newSequence[rune](&runesSequence{s: s})
+}
+
+type runesSequence struct {
+	s string
+}
+
+var _ seq[rune] = (*runesSequence)(nil)
+
+func (s *runesSequence) Next() (rune, bool) {
+	if s.s == "" {
+		return 0, false
+	}
+	r, size := utf8.DecodeRuneInString(s.s)
+	s.s = s.s[size:]
+	return r, true
+}
+
+func (s *runesSequence) Err() error {
+	return nil
+}
+
+func (s *runesSequence) String() string {
+	return "runes"
+}
+
+// Fields returns a sequence that lazily splits s around runs of white space, like strings.Fields.
+func Fields(s string) Sequence[string] {
+	return newSequence[string](&fieldsSequence{s: s})
+}
+
+type fieldsSequence struct {
+	s string
+}
+
+var _ seq[string] = (*fieldsSequence)(nil)
+
+func (s *fieldsSequence) Next() (string, bool) {
+	s.s = strings.TrimLeftFunc(s.s, unicode.IsSpace)
+	if s.s == "" {
+		return "", false
+	}
+	end := strings.IndexFunc(s.s, unicode.IsSpace)
+	if end < 0 {
+		end = len(s.s)
+	}
+	e := s.s[:end]
+	s.s = s.s[end:]
+	return e, true
+}
+
+func (s *fieldsSequence) Err() error {
+	return nil
+}
+
+func (s *fieldsSequence) String() string {
+	return "fields"
+}
+
+// RegexpMatches returns a sequence of successive non-overlapping matches of re in s, like re.FindAllString.
+// Matches are searched in batches of growing size, so taking the first few matches does not scan all of s.
+func RegexpMatches(re *regexp.Regexp, s string) Sequence[string] {
+	return newSequence[string](&regexpMatchesSequence{re: re, s: s})
+}
+
+type regexpMatchesSequence struct {
+	re        *regexp.Regexp
+	s         string
+	matches   [][]int
+	i         int
+	exhausted bool
+}
+
+var _ seq[string] = (*regexpMatchesSequence)(nil)
+
+func (s *regexpMatchesSequence) Next() (string, bool) {
+	if s.i == len(s.matches) && !s.exhausted {
+		// FindAllStringIndex cannot resume from an offset without losing the context of anchors such as ^ and \b,
+		// so it is called again with a doubled limit.
+		n := max(2*len(s.matches), 1)
+		s.matches = s.re.FindAllStringIndex(s.s, n)
+		s.exhausted = len(s.matches) < n
+	}
+	if s.i == len(s.matches) {
+		return "", false
+	}
+	m := s.matches[s.i]
+	s.i++
+	return s.s[m[0]:m[1]], true
+}
+
+func (s *regexpMatchesSequence) Err() error {
+	return nil
+}
+
+func (s *regexpMatchesSequence) String() string {
+	return fmt.Sprintf("regexp matches of %s", s.re)
+}
@@ -0,0 +1,82 @@
+package kol
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "lines", input: "a\nb\r\nc", want: []string{"a", "b", "c"}},
+		{name: "trailing newline", input: "a\n\nb\n", want: []string{"a", "", "b"}},
+		{name: "empty", input: "", want: []string{}},
+		{name: "long line", input: strings.Repeat("x", 100_000), want: []string{strings.Repeat("x", 100_000)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Lines(strings.NewReader(tt.input))
+			assert.Equal(t, tt.want, s.ToSlice())
+			assert.NoError(t, s.Err())
+		})
+	}
+}
+
+func TestLines_Error(t *testing.T) {
+	errRead := errors.New("read failed")
+	s := Lines(iotest.TimeoutReader(strings.NewReader("a\nb")))
+	assert.Equal(t, []string{"a"}, s.ToSlice())
+	assert.ErrorIs(t, s.Err(), iotest.ErrTimeout)
+
+	s = Lines(iotest.ErrReader(errRead))
+	assert.Empty(t, s.ToSlice())
+	assert.ErrorIs(t, s.Err(), errRead)
+}
+
+func TestSplitSeq(t *testing.T) {
+	tests := []struct {
+		s, sep string
+	}{
+		{s: "a,b,,c", sep: ","},
+		{s: "a,b,", sep: ","},
+		{s: "", sep: ","},
+		{s: "abc", sep: ""},
+		{s: "日本語", sep: ""},
+		{s: "", sep: ""},
+		{s: "a--b", sep: "--"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s+" by "+tt.sep, func(t *testing.T) {
+			assert.Equal(t, strings.Split(tt.s, tt.sep), SplitSeq(tt.s, tt.sep).ToSlice())
+		})
+	}
+}
+
+func TestRunes(t *testing.T) {
+	assert.Equal(t, []rune("日本go"), Runes("日本go").ToSlice())
+	assert.Equal(t, []rune{'a', '�'}, Runes("a\xff").ToSlice())
+	assert.Empty(t, Runes("").ToSlice())
+}
+
+func TestFields(t *testing.T) {
+	for _, s := range []string{"  a b\t\nc  ", "", "   ", "one", "a　b"} {
+		assert.Equal(t, append([]string{}, strings.Fields(s)...), Fields(s).ToSlice())
+	}
+}
+
+func TestRegexpMatches(t *testing.T) {
+	re := regexp.MustCompile(`\b\w`)
+	input := "the quick brown fox jumps over the lazy dog"
+	assert.Equal(t, re.FindAllString(input, -1), RegexpMatches(re, input).ToSlice())
+	assert.Equal(t, []string{"t", "q"}, RegexpMatches(re, input).Take(2).ToSlice())
+	assert.Equal(t, []string{"", "", ""}, RegexpMatches(regexp.MustCompile(`x*`), "ab").ToSlice())
+	assert.Empty(t, RegexpMatches(re, "").ToSlice())
+}
@@ -0,0 +1,108 @@
+package kol
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// WalkEntry is a file or directory visited by WalkSequence.
+type WalkEntry struct {
+	fs.DirEntry
+	// Path is the path of the entry, which is root joined with the path from root.
+	Path string
+}
+
+// WalkSequence returns a sequence that lazily walks the file tree rooted at root in fsys, like fs.WalkDir.
+// It yields root and then every file and directory in lexical order, descending into a directory right after it.
+// Directories are read only when the walk reaches them.
+// The sequence stops at the first error, which Err returns.
+func WalkSequence(fsys fs.FS, root string) Sequence[WalkEntry] {
+	return WalkSequenceFunc(fsys, root, nil)
+}
+
+// WalkSequenceFunc returns a sequence that walks the file tree like WalkSequence, but skips entries matching skip.
+// A skipped directory is not descended into, like returning fs.SkipDir from the function of fs.WalkDir.
+func WalkSequenceFunc(fsys fs.FS, root string, skip func(entry WalkEntry) bool) Sequence[WalkEntry] {
+	if skip == nil {
+		skip = func(WalkEntry) bool { return false }
+	}
+	return newSequence[WalkEntry](&walkSequence{fsys: fsys, root: root, skip: skip})
+}
+
+type walkSequence struct {
+	fsys    fs.FS
+	root    string
+	skip    func(entry WalkEntry) bool
+	started bool
+	// stack holds directories being walked, from root to the deepest one.
+	stack []walkDir
+	err   error
+}
+
+type walkDir struct {
+	path    string
+	read    bool
+	entries []fs.DirEntry
+}
+
+var _ seq[WalkEntry] = (*walkSequence)(nil)
+
+func (s *walkSequence) Next() (WalkEntry, bool) {
+	if s.err != nil {
+		return WalkEntry{}, false
+	}
+	if !s.started {
+		s.started = true
+		info, err := fs.Stat(s.fsys, s.root)
+		if err != nil {
+			s.err = err
+			return WalkEntry{}, false
+		}
+		if e, ok := s.visit(s.root, fs.FileInfoToDirEntry(info)); ok {
+			return e, true
+		}
+	}
+	for len(s.stack) > 0 {
+		dir := &s.stack[len(s.stack)-1]
+		if !dir.read {
+			entries, err := fs.ReadDir(s.fsys, dir.path)
+			if err != nil {
+				s.err = err
+				return WalkEntry{}, false
+			}
+			dir.read = true
+			dir.entries = entries
+		}
+		if len(dir.entries) == 0 {
+			s.stack = s.stack[:len(s.stack)-1]
+			continue
+		}
+		d := dir.entries[0]
+		dir.entries = dir.entries[1:]
+		if e, ok := s.visit(path.Join(dir.path, d.Name()), d); ok {
+			return e, true
+		}
+	}
+	return WalkEntry{}, false
+}
+
+// visit returns the entry of the given path unless it is skipped, and schedules walking it if it is a directory.
+func (s *walkSequence) visit(p string, d fs.DirEntry) (WalkEntry, bool) {
+	e := WalkEntry{DirEntry: d, Path: p}
+	if s.skip(e) {
+		return WalkEntry{}, false
+	}
+	if d.IsDir() {
+		s.stack = append(s.stack, walkDir{path: p})
+	}
+	return e, true
+}
+
+func (s *walkSequence) Err() error {
+	return s.err
+}
+
+func (s *walkSequence) String() string {
+	return fmt.Sprintf("walk %s", s.root)
+}
@@ -0,0 +1,93 @@
+package kol
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newWalkFS() fstest.MapFS {
+	return fstest.MapFS{
+		"config/app.yaml":        {},
+		"config/local/dev.yaml":  {},
+		"assets/logo.png":        {},
+		"assets/css/site.css":    {},
+		"node_modules/x/a.js":    {},
+		"README.md":              {},
+		"config/local/notes.txt": {},
+	}
+}
+
+func walkPaths(s Sequence[WalkEntry]) []string {
+	return MapSequence(s, func(e WalkEntry) string { return e.Path }).ToSlice()
+}
+
+func TestWalkSequence(t *testing.T) {
+	fsys := newWalkFS()
+	var want []string
+	assert.NoError(t, fs.WalkDir(fsys, ".", func(p string, _ fs.DirEntry, err error) error {
+		want = append(want, p)
+		return err
+	}))
+
+	s := WalkSequence(fsys, ".")
+	assert.Equal(t, want, walkPaths(s))
+	assert.NoError(t, s.Err())
+
+	assert.Equal(t, []string{"config/app.yaml", "config/local"}, walkPaths(WalkSequence(fsys, "config").Drop(1).Take(2)))
+	assert.Equal(t, []string{"README.md"}, walkPaths(WalkSequence(fsys, "README.md")))
+}
+
+func TestWalkSequence_Filter(t *testing.T) {
+	yaml := WalkSequence(newWalkFS(), ".").Filter(func(e WalkEntry) bool {
+		return !e.IsDir() && strings.HasSuffix(e.Name(), ".yaml")
+	})
+	assert.Equal(t, []string{"config/app.yaml", "config/local/dev.yaml"}, walkPaths(yaml))
+}
+
+func TestWalkSequenceFunc(t *testing.T) {
+	s := WalkSequenceFunc(newWalkFS(), ".", func(e WalkEntry) bool {
+		return e.IsDir() && (e.Name() == "node_modules" || e.Name() == "local")
+	})
+	assert.Equal(t, []string{
+		".", "README.md", "assets", "assets/css", "assets/css/site.css", "assets/logo.png", "config", "config/app.yaml",
+	}, walkPaths(s))
+}
+
+// lazyFS counts directories read, and fails to read a directory named bad.
+type lazyFS struct {
+	fstest.MapFS
+	reads int
+}
+
+func (f *lazyFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f.reads++
+	if name == "bad" {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return f.MapFS.ReadDir(name)
+}
+
+func TestWalkSequence_Lazy(t *testing.T) {
+	fsys := &lazyFS{MapFS: newWalkFS()}
+	assert.Equal(t, []string{".", "README.md"}, walkPaths(WalkSequence(fsys, ".").Take(2)))
+	assert.Equal(t, 1, fsys.reads)
+}
+
+func TestWalkSequence_Error(t *testing.T) {
+	s := WalkSequence(newWalkFS(), "missing")
+	assert.Empty(t, walkPaths(s))
+	assert.ErrorIs(t, s.Err(), fs.ErrNotExist)
+
+	fsys := &lazyFS{MapFS: fstest.MapFS{"a.txt": {}, "bad/b.txt": {}, "c.txt": {}}}
+	s = WalkSequence(fsys, ".")
+	assert.Equal(t, []string{".", "a.txt", "bad"}, walkPaths(s))
+	var pathErr *fs.PathError
+	assert.True(t, errors.As(s.Err(), &pathErr))
+	assert.Equal(t, "bad", pathErr.Path)
+	assert.ErrorIs(t, s.Err(), fs.ErrPermission)
+}